@@ -7,23 +7,98 @@ import (
 	goembed "github.com/indalyadav56/gogen"
 	"github.com/indalyadav56/gogen/internal/cli"
 	"github.com/indalyadav56/gogen/internal/generator"
+	"github.com/indalyadav56/gogen/internal/openapi"
+	"github.com/indalyadav56/gogen/internal/scaffold"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "add" {
+		runAdd(os.Args[2:])
+		return
+	}
+
 	// Parse command line flags
 	config := cli.ParseFlags()
+	if config.FromOpenAPI != "" {
+		warnings, err := openapi.Apply(config, config.FromOpenAPI)
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		exitWithError(err)
+	}
 	
 	// Create project generator with embedded templates
 	projectGen := generator.NewProjectGenerator(config, goembed.TemplateFS)
 	
+	if config.DryRun {
+		if err := writePlan(projectGen, config.PlanFormat); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+	
 	// Generate the project
-	if err := projectGen.Generate(); err != nil {
+	err := projectGen.Generate()
+	printSummary(projectGen.Summary())
+	if err != nil {
 		exitWithError(err)
 	}
 	
 	fmt.Println("✅ Project structure scaffolded successfully.")
 }
 
+// printSummary lists the existing files that were skipped or overwritten
+func printSummary(summary scaffold.WriteSummary) {
+	if len(summary.Overwritten) > 0 {
+		fmt.Printf("Overwritten (%d):\n", len(summary.Overwritten))
+		for _, path := range summary.Overwritten {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if len(summary.Skipped) > 0 {
+		fmt.Printf("Skipped (%d):\n", len(summary.Skipped))
+		for _, path := range summary.Skipped {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if len(summary.Unchanged) > 0 {
+		fmt.Printf("Unchanged: %d existing files already up to date\n", len(summary.Unchanged))
+	}
+}
+
+// writePlan prints the generation plan in the requested format
+func writePlan(projectGen *generator.ProjectGenerator, format string) error {
+	plan := projectGen.Plan()
+	if format == "json" {
+		return plan.WriteJSON(os.Stdout)
+	}
+	return plan.WriteText(os.Stdout)
+}
+
+// runAdd adds a bounded context to an existing project
+func runAdd(args []string) {
+	addConfig, err := cli.ParseAddArgs(args, os.Stderr)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	adder, err := generator.NewEntityAdder(addConfig.Dir, goembed.TemplateFS)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := adder.Add(addConfig.EntityName, addConfig.Fields); err != nil {
+		exitWithError(err)
+	}
+
+	fmt.Printf("✅ Entity %s added and wired into cmd/main.go.\n", addConfig.EntityName)
+}
+
 // exitWithError prints an error message and exits with status 1
 func exitWithError(err error) {
 	fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/utils"
+)
+
+// AddConfig holds configuration for the add subcommand
+type AddConfig struct {
+	Kind       string
+	EntityName string
+	Dir        string
+	Fields     schema.Fields
+}
+
+// AddUsage describes the add subcommand
+const AddUsage = "usage: gogen add entity <name> [--dir path] [--field <name>:field:type[:required][:unique]...]"
+
+// ParseAddArgs parses the arguments following "gogen add"
+func ParseAddArgs(args []string, output io.Writer) (*AddConfig, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("missing arguments\n%s", AddUsage)
+	}
+	if args[0] != "entity" {
+		return nil, fmt.Errorf("unknown add target %q\n%s", args[0], AddUsage)
+	}
+
+	config := &AddConfig{Kind: args[0], EntityName: args[1]}
+	key := utils.ToCamelCase(config.EntityName)
+
+	fs := flag.NewFlagSet("gogen add entity", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&config.Dir, "dir", ".", "root directory of the existing gogen project")
+	fields := fieldSpecs{}
+	fs.Var(fields, "field", "Entity field as entity:name:type[:required][:unique]. Example: --field product:price:decimal:required")
+
+	if err := fs.Parse(args[2:]); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %v\n%s", fs.Args(), AddUsage)
+	}
+
+	for entityName := range fields {
+		if entityName != key {
+			return nil, fmt.Errorf("field defined for entity %q, expected %q", entityName, config.EntityName)
+		}
+	}
+	config.Fields = fields[key]
+
+	return config, nil
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/schema"
+)
+
+func TestParseAddArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected *AddConfig
+		wantErr  bool
+	}{
+		{
+			name:     "entity with defaults",
+			args:     []string{"entity", "product"},
+			expected: &AddConfig{Kind: "entity", EntityName: "product", Dir: "."},
+		},
+		{
+			name: "entity with dir and fields",
+			args: []string{"entity", "product", "--dir", "shop", "--field", "product:name:string:required"},
+			expected: &AddConfig{
+				Kind:       "entity",
+				EntityName: "product",
+				Dir:        "shop",
+				Fields:     schema.Fields{{Name: "name", Type: "string", Required: true}},
+			},
+		},
+		{
+			name:    "missing name",
+			args:    []string{"entity"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown target",
+			args:    []string{"service", "billing"},
+			wantErr: true,
+		},
+		{
+			name:    "field for another entity",
+			args:    []string{"entity", "product", "--field", "order:total:decimal"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseAddArgs(tt.args, io.Discard)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ParseAddArgs() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAddArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("ParseAddArgs() = %+v, want %+v", config, tt.expected)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package cli
 
 import (
 	"flag"
+	"fmt"
 	"strings"
+
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/utils"
 )
 
 // stringSlice implements flag.Value interface for handling multiple string flags
@@ -17,6 +21,88 @@ func (s *stringSlice) Set(value string) error {
 	return nil
 }
 
+// fieldSpecs implements flag.Value interface for collecting entity field definitions
+type fieldSpecs map[string]schema.Fields
+
+func (f fieldSpecs) String() string {
+	var specs []string
+	for entityName, fields := range f {
+		for _, field := range fields {
+			specs = append(specs, fmt.Sprintf("%s:%s:%s", entityName, field.Name, field.Type))
+		}
+	}
+	return strings.Join(specs, ",")
+}
+
+func (f fieldSpecs) Set(value string) error {
+	entityName, field, err := schema.ParseFieldSpec(value)
+	if err != nil {
+		return err
+	}
+	key := utils.ToCamelCase(entityName)
+	fields, err := f[key].Add(field)
+	if err != nil {
+		return fmt.Errorf("invalid field spec %q: %w", value, err)
+	}
+	f[key] = fields
+	return nil
+}
+
+// Optional components that can be switched on or off in a project spec
+const (
+	ComponentDocker   = "docker"
+	ComponentTaskfile = "taskfile"
+	// ComponentMigrate embeds the migrations and adds a migrate subcommand to the generated binary
+	ComponentMigrate = "migrate"
+	// ComponentSwagger serves the OpenAPI spec and a Swagger UI page at /docs
+	ComponentSwagger = "swagger"
+	// ComponentTests adds service and handler tests over a mock repository to every entity
+	ComponentTests = "tests"
+)
+
+// knownComponents lists every optional component, all of which are generated by default
+var knownComponents = []string{ComponentDocker, ComponentTaskfile, ComponentMigrate, ComponentSwagger, ComponentTests}
+
+// HTTP frameworks a project can be generated for
+const (
+	FrameworkChi   = "chi"
+	FrameworkGin   = "gin"
+	FrameworkEcho  = "echo"
+	FrameworkFiber = "fiber"
+	// FrameworkStdlib routes with the Go 1.22 net/http ServeMux and no third-party router
+	FrameworkStdlib = "stdlib"
+)
+
+// KnownFrameworks lists every supported HTTP framework; chi is the default
+var KnownFrameworks = []string{FrameworkChi, FrameworkGin, FrameworkEcho, FrameworkFiber, FrameworkStdlib}
+
+// Databases a project can persist to
+const (
+	DBPostgres = "postgres"
+	DBMySQL    = "mysql"
+	DBSQLite   = "sqlite"
+	DBMongo    = "mongo"
+)
+
+// KnownDatabases lists every supported database; postgres is the default
+var KnownDatabases = []string{DBPostgres, DBMySQL, DBSQLite, DBMongo}
+
+// ORMGorm generates GORM repositories and connection setup instead of hand-written SQL
+const ORMGorm = "gorm"
+
+// KnownORMs lists every supported ORM; empty means plain database drivers
+var KnownORMs = []string{ORMGorm}
+
+// Pagination modes of the generated list endpoints
+const (
+	PaginationOffset = "offset"
+	// PaginationCursor pages with signed keyset cursors instead of page numbers
+	PaginationCursor = "cursor"
+)
+
+// KnownPaginations lists every supported pagination mode; offset is the default
+var KnownPaginations = []string{PaginationOffset, PaginationCursor}
+
 // Config holds all CLI configuration
 type Config struct {
 	ModuleName string
@@ -24,27 +110,124 @@ type Config struct {
 	Entities   []string
 	UseGin     bool
 	UseAuth    bool
+	// Framework selects the HTTP framework; empty falls back to UseGin, then chi
+	Framework string
+	// DB selects the database; empty means postgres
+	DB string
+	// UsePgx talks to postgres through pgxpool instead of database/sql
+	UsePgx bool
+	// UseSQLC generates sqlc queries and repositories wrapping the sqlc-generated code
+	UseSQLC bool
+	// ORM selects an ORM for the repositories; empty means plain database drivers
+	ORM string
+	// UseGRPC adds a gRPC transport next to HTTP
+	UseGRPC bool
+	// UseIntegration generates build-tagged integration tests running the repositories against a real database
+	UseIntegration bool
+	// Pagination selects how list endpoints page; empty means offset
+	Pagination string
+	// Fields maps an entity name (camel case) to its field definitions
+	Fields map[string]schema.Fields
+	// Components restricts optional components; nil means all of them
+	Components []string
+	// DryRun prints the generation plan instead of writing files
+	DryRun bool
+	// PlanFormat selects the dry-run output format (text or json)
+	PlanFormat string
+	// OnConflict decides what happens to files that already exist (fail, skip, overwrite, prompt or diff)
+	OnConflict string
+	// FromOpenAPI is an OpenAPI document to derive entities and fields from
+	FromOpenAPI string
+	// RoutePaths overrides the base path of an entity's routes, keyed like Fields
+	RoutePaths map[string]string
 }
 
 // ParseFlags parses command line flags and returns configuration
+// Flags given explicitly take precedence over values from a --config spec file
 func ParseFlags() *Config {
 	config := &Config{}
 	
 	moduleFlag := flag.String("module", "github.com/username/golang_project", "Go module name (e.g. github.com/user/project)")
 	monolithFlag := flag.Bool("monolith", false, "for monolith architecture")
-	ginFlag := flag.Bool("gin", false, "use Gin framework instead of Chi for HTTP routing")
+	ginFlag := flag.Bool("gin", false, "use Gin framework instead of Chi for HTTP routing (same as --framework=gin)")
+	frameworkFlag := flag.String("framework", "", "HTTP framework: "+strings.Join(KnownFrameworks, ", ")+" (default chi)")
 	authFlag := flag.Bool("auth", false, "generate RBAC-based authentication system with JWT")
+	dbFlag := flag.String("db", "", "database: "+strings.Join(KnownDatabases, ", ")+" (default postgres)")
+	pgxFlag := flag.Bool("pgx", false, "use pgx's pgxpool instead of database/sql (postgres only)")
+	ormFlag := flag.String("orm", "", "ORM for the repositories: "+strings.Join(KnownORMs, ", ")+" (default none)")
+	sqlcFlag := flag.Bool("sqlc", false, "generate sqlc.yaml, queries/*.sql and repositories wrapping the sqlc code (postgres only)")
+	grpcFlag := flag.Bool("grpc", false, "generate .proto files, gRPC servers and a gRPC listener next to HTTP")
+	integrationFlag := flag.Bool("integration", false, "generate test/integration with repository tests run by go test -tags integration")
+	paginationFlag := flag.String("pagination", "", "list pagination: "+strings.Join(KnownPaginations, ", ")+" (default offset)")
 	
 	var entities stringSlice
 	flag.Var(&entities, "entity", "Specify one or more entity names. Example: --entity User --entity Product")
+
+	fields := fieldSpecs{}
+	flag.Var(fields, "field", "Entity field as entity:name:type[:required][:unique]. Example: --field product:price:decimal:required")
+
+	flag.BoolVar(&config.DryRun, "dry-run", false, "print the directories and files that would be generated without writing them")
+	flag.StringVar(&config.PlanFormat, "plan-format", "", "dry-run output format: text (default) or json")
+	flag.StringVar(&config.FromOpenAPI, "from-openapi", "", "derive entities and fields from an OpenAPI 3 document (e.g. api.yaml)")
+	flag.StringVar(&config.OnConflict, "on-conflict", "", "policy for existing files: fail (default), skip, overwrite, prompt or diff")
+
+	specLoaded := false
+	flag.Func("config", "load project spec from a YAML or JSON file (e.g. gogen.yaml)", func(path string) error {
+		specLoaded = true
+		return config.LoadSpec(path)
+	})
 	
 	flag.Parse()
 	
-	config.ModuleName = *moduleFlag
-	config.Monolith = *monolithFlag
-	config.Entities = entities
-	config.UseGin = *ginFlag
-	config.UseAuth = *authFlag
+	if !specLoaded {
+		config.ModuleName = *moduleFlag
+	}
+	// Visit walks flags in lexicographical order, so --framework is seen before --gin
+	frameworkGiven := false
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "db":
+			config.DB = *dbFlag
+		case "pgx":
+			config.UsePgx = *pgxFlag
+		case "orm":
+			config.ORM = *ormFlag
+		case "sqlc":
+			config.UseSQLC = *sqlcFlag
+		case "framework":
+			config.Framework = *frameworkFlag
+			frameworkGiven = true
+		case "module":
+			config.ModuleName = *moduleFlag
+		case "monolith":
+			config.Monolith = *monolithFlag
+		case "gin":
+			config.UseGin = *ginFlag
+			if *ginFlag && !frameworkGiven {
+				// --gin overrides the framework from a spec file
+				config.Framework = ""
+			}
+		case "auth":
+			config.UseAuth = *authFlag
+		case "grpc":
+			config.UseGRPC = *grpcFlag
+		case "integration":
+			config.UseIntegration = *integrationFlag
+		case "pagination":
+			config.Pagination = *paginationFlag
+		}
+	})
+	for _, entityName := range entities {
+		if !config.hasEntity(entityName) {
+			config.Entities = append(config.Entities, entityName)
+		}
+	}
+	for entityName, entityFields := range fields {
+		if config.Fields == nil {
+			config.Fields = make(map[string]schema.Fields)
+		}
+		config.Fields[entityName] = append(config.Fields[entityName], entityFields...)
+	}
 	
 	return config
 }
@@ -54,3 +237,220 @@ func (c *Config) GetProjectRoot() string {
 	moduleParts := strings.Split(c.ModuleName, "/")
 	return moduleParts[len(moduleParts)-1]
 }
+
+// Validate checks the configuration for inconsistencies
+func (c *Config) Validate() error {
+	declared := make(map[string]bool, len(c.Entities))
+	for _, entityName := range c.Entities {
+		declared[utils.ToCamelCase(entityName)] = true
+	}
+
+	for entityName, fields := range c.Fields {
+		if !declared[entityName] {
+			return fmt.Errorf("fields defined for undeclared entity %q (add --entity %s)", entityName, entityName)
+		}
+		var checked schema.Fields
+		for _, field := range fields {
+			var err error
+			if checked, err = checked.Add(field); err != nil {
+				return fmt.Errorf("entity %q: %w", entityName, err)
+			}
+			if field.References != "" && !c.Monolith {
+				return fmt.Errorf("entity %q: field %q references %s, which requires --monolith", entityName, field.Name, field.References)
+			}
+		}
+	}
+
+	if !isKnownFramework(c.Framework) {
+		return fmt.Errorf("unknown framework %q (supported: %s)", c.Framework, strings.Join(KnownFrameworks, ", "))
+	}
+	if c.UseGin && c.Framework != "" && c.Framework != FrameworkGin {
+		return fmt.Errorf("--gin conflicts with --framework=%s", c.Framework)
+	}
+	if framework := c.HTTPFramework(); c.UseAuth && framework != FrameworkChi && framework != FrameworkGin {
+		// the auth templates only have chi and gin variants
+		return fmt.Errorf("--auth is not supported with --framework=%s yet (supported: chi, gin)", framework)
+	}
+
+	if !isKnownDatabase(c.DB) {
+		return fmt.Errorf("unknown database %q (supported: %s)", c.DB, strings.Join(KnownDatabases, ", "))
+	}
+	if c.UsePgx && c.Database() != DBPostgres {
+		return fmt.Errorf("--pgx requires --db=postgres, got --db=%s", c.Database())
+	}
+	if c.UseSQLC && c.Database() != DBPostgres {
+		return fmt.Errorf("--sqlc requires --db=postgres, got --db=%s", c.Database())
+	}
+	if !isKnownORM(c.ORM) {
+		return fmt.Errorf("unknown ORM %q (supported: %s)", c.ORM, strings.Join(KnownORMs, ", "))
+	}
+	if c.UseGORM() {
+		// the pure-Go GORM SQLite driver registers the same database/sql name as modernc.org/sqlite
+		if db := c.Database(); db != DBPostgres && db != DBMySQL {
+			return fmt.Errorf("--orm=gorm supports --db=postgres and --db=mysql, got --db=%s", db)
+		}
+		if c.UsePgx || c.UseSQLC {
+			return fmt.Errorf("--orm=gorm cannot be combined with --pgx or --sqlc")
+		}
+	}
+	if c.UseAuth && (c.Database() != DBPostgres || c.UsePgx) {
+		// the auth repositories are written against PostgreSQL on database/sql or GORM
+		return fmt.Errorf("--auth is only supported with --db=postgres without --pgx")
+	}
+	if !isKnownPagination(c.Pagination) {
+		return fmt.Errorf("unknown pagination %q (supported: %s)", c.Pagination, strings.Join(KnownPaginations, ", "))
+	}
+	if c.UseCursor() && c.UseSQLC {
+		// sqlc queries are static, so they cannot seek on the sort column picked per request
+		return fmt.Errorf("--pagination=cursor cannot be combined with --sqlc")
+	}
+	if c.UseIntegration {
+		// the harness prepares the database with the generated SQL migrations
+		if c.Database() == DBMongo {
+			return fmt.Errorf("--integration requires a SQL database, got --db=%s", c.Database())
+		}
+		if !c.HasComponent(ComponentMigrate) {
+			return fmt.Errorf("--integration requires the %s component", ComponentMigrate)
+		}
+	}
+
+	switch c.PlanFormat {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("unknown plan format %q (supported: text, json)", c.PlanFormat)
+	}
+
+	switch c.OnConflict {
+	case "", "fail", "skip", "overwrite", "prompt", "diff":
+	default:
+		return fmt.Errorf("unknown conflict policy %q (supported: fail, skip, overwrite, prompt, diff)", c.OnConflict)
+	}
+
+	for _, name := range c.Components {
+		if !isKnownComponent(name) {
+			return fmt.Errorf("unknown component %q (supported: %s)", name, strings.Join(knownComponents, ", "))
+		}
+	}
+
+	return nil
+}
+
+// HTTPFramework returns the framework to generate, honoring the legacy --gin switch
+func (c *Config) HTTPFramework() string {
+	switch {
+	case c.Framework != "":
+		return c.Framework
+	case c.UseGin:
+		return FrameworkGin
+	default:
+		return FrameworkChi
+	}
+}
+
+// Database returns the database to generate for, defaulting to postgres
+func (c *Config) Database() string {
+	if c.DB == "" {
+		return DBPostgres
+	}
+	return c.DB
+}
+
+// SQLDatabase reports whether the database is queried with SQL and managed with migrations
+func (c *Config) SQLDatabase() bool {
+	return c.Database() != DBMongo
+}
+
+// UseGORM reports whether the repositories are generated on GORM
+func (c *Config) UseGORM() bool {
+	return c.ORM == ORMGorm
+}
+
+// UseCursor reports whether list endpoints page with keyset cursors
+func (c *Config) UseCursor() bool {
+	return c.Pagination == PaginationCursor
+}
+
+// hasEntity reports whether an entity is already declared, ignoring case style differences
+func (c *Config) hasEntity(name string) bool {
+	for _, entityName := range c.Entities {
+		if utils.ToCamelCase(entityName) == utils.ToCamelCase(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasComponent reports whether an optional component should be generated
+func (c *Config) HasComponent(name string) bool {
+	if c.Components == nil {
+		return true
+	}
+	for _, component := range c.Components {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownComponent reports whether name is a supported optional component
+func isKnownComponent(name string) bool {
+	for _, component := range knownComponents {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownFramework reports whether name is a supported HTTP framework; empty means the default
+func isKnownFramework(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, framework := range KnownFrameworks {
+		if framework == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownDatabase reports whether name is a supported database; empty means the default
+func isKnownDatabase(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, db := range KnownDatabases {
+		if db == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownORM reports whether name is a supported ORM; empty means none
+func isKnownORM(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, orm := range KnownORMs {
+		if orm == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownPagination reports whether name is a supported pagination mode; empty means the default
+func isKnownPagination(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, pagination := range KnownPaginations {
+		if pagination == name {
+			return true
+		}
+	}
+	return false
+}
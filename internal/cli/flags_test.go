@@ -5,6 +5,8 @@ import (
 	"os"
 	"reflect"
 	"testing"
+
+	"github.com/indalyadav56/gogen/internal/schema"
 )
 
 func TestParseFlags(t *testing.T) {
@@ -152,3 +154,326 @@ func TestStringSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFlags_Fields(t *testing.T) {
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	oldArgs := os.Args
+	os.Args = []string{"gogen",
+		"--entity", "product",
+		"--field", "product:name:string:required",
+		"--field", "product:price:decimal",
+	}
+	defer func() { os.Args = oldArgs }()
+
+	config := ParseFlags()
+
+	expected := map[string]schema.Fields{
+		"product": {
+			{Name: "name", Type: "string", Required: true},
+			{Name: "price", Type: "decimal"},
+		},
+	}
+	if !reflect.DeepEqual(config.Fields, expected) {
+		t.Errorf("Fields = %+v, want %+v", config.Fields, expected)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestFieldSpecs_SetDuplicate(t *testing.T) {
+	fields := fieldSpecs{}
+	if err := fields.Set("product:name:string"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := fields.Set("order:name:string"); err != nil {
+		t.Errorf("Set() same name on another entity error = %v", err)
+	}
+	if err := fields.Set("product:name:int"); err == nil {
+		t.Error("Set() expected error for duplicate field, got nil")
+	}
+	if len(fields["product"]) != 1 {
+		t.Errorf("product fields = %+v, want only the first name", fields["product"])
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "no fields",
+			config: Config{Entities: []string{"user"}},
+		},
+		{
+			name: "fields for declared entity",
+			config: Config{
+				Entities: []string{"order-item"},
+				Fields:   map[string]schema.Fields{"orderItem": {{Name: "quantity", Type: "int"}}},
+			},
+		},
+		{
+			name: "duplicate field",
+			config: Config{
+				Entities: []string{"product"},
+				Fields:   map[string]schema.Fields{"product": {{Name: "name", Type: "string"}, {Name: "name", Type: "int"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "relation in a monolith",
+			config: Config{
+				Monolith: true,
+				Entities: []string{"customer", "order"},
+				Fields:   map[string]schema.Fields{"order": {{Name: "customer_id", Type: "uuid", References: "customer"}}},
+			},
+		},
+		{
+			name: "relation in a microservice",
+			config: Config{
+				Entities: []string{"customer", "order"},
+				Fields:   map[string]schema.Fields{"order": {{Name: "customer_id", Type: "uuid", References: "customer"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown plan format",
+			config:  Config{PlanFormat: "yaml"},
+			wantErr: true,
+		},
+		{
+			name:   "known conflict policy",
+			config: Config{OnConflict: "skip"},
+		},
+		{
+			name:    "unknown conflict policy",
+			config:  Config{OnConflict: "merge"},
+			wantErr: true,
+		},
+		{
+			name:   "known framework",
+			config: Config{Framework: FrameworkEcho},
+		},
+		{
+			name:    "unknown framework",
+			config:  Config{Framework: "martini"},
+			wantErr: true,
+		},
+		{
+			name:    "gin flag conflicting with framework",
+			config:  Config{UseGin: true, Framework: FrameworkFiber},
+			wantErr: true,
+		},
+		{
+			name:    "auth with a framework lacking auth templates",
+			config:  Config{UseAuth: true, Framework: FrameworkEcho},
+			wantErr: true,
+		},
+		{
+			name:   "known database",
+			config: Config{DB: DBSQLite},
+		},
+		{
+			name:    "unknown database",
+			config:  Config{DB: "oracle"},
+			wantErr: true,
+		},
+		{
+			name:   "pgx with default database",
+			config: Config{UsePgx: true},
+		},
+		{
+			name:    "pgx with mysql",
+			config:  Config{DB: DBMySQL, UsePgx: true},
+			wantErr: true,
+		},
+		{
+			name:   "sqlc with pgx",
+			config: Config{UseSQLC: true, UsePgx: true},
+		},
+		{
+			name:    "sqlc with sqlite",
+			config:  Config{DB: DBSQLite, UseSQLC: true},
+			wantErr: true,
+		},
+		{
+			name:   "gorm with mysql",
+			config: Config{DB: DBMySQL, ORM: ORMGorm},
+		},
+		{
+			name:    "gorm with sqlite",
+			config:  Config{DB: DBSQLite, ORM: ORMGorm},
+			wantErr: true,
+		},
+		{
+			name:    "gorm with pgx",
+			config:  Config{ORM: ORMGorm, UsePgx: true},
+			wantErr: true,
+		},
+		{
+			name:    "unknown orm",
+			config:  Config{ORM: "ent"},
+			wantErr: true,
+		},
+		{
+			name:   "cursor pagination",
+			config: Config{Pagination: PaginationCursor, DB: DBMongo},
+		},
+		{
+			name:    "cursor pagination with sqlc",
+			config:  Config{Pagination: PaginationCursor, UseSQLC: true},
+			wantErr: true,
+		},
+		{
+			name:    "unknown pagination",
+			config:  Config{Pagination: "page"},
+			wantErr: true,
+		},
+		{
+			name:    "auth with mysql",
+			config:  Config{DB: DBMySQL, UseAuth: true},
+			wantErr: true,
+		},
+		{
+			name:   "mongo",
+			config: Config{DB: DBMongo, Framework: FrameworkEcho},
+		},
+		{
+			name:    "pgx with mongo",
+			config:  Config{DB: DBMongo, UsePgx: true},
+			wantErr: true,
+		},
+		{
+			name:   "integration with sqlite",
+			config: Config{DB: DBSQLite, UseIntegration: true},
+		},
+		{
+			name:    "integration with mongo",
+			config:  Config{DB: DBMongo, UseIntegration: true},
+			wantErr: true,
+		},
+		{
+			name:    "integration without migrations",
+			config:  Config{UseIntegration: true, Components: []string{ComponentDocker}},
+			wantErr: true,
+		},
+		{
+			name: "fields for undeclared entity",
+			config: Config{
+				Entities: []string{"user"},
+				Fields:   map[string]schema.Fields{"product": {{Name: "name", Type: "string"}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_HTTPFramework(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{name: "default", config: Config{}, expected: FrameworkChi},
+		{name: "gin flag", config: Config{UseGin: true}, expected: FrameworkGin},
+		{name: "framework flag", config: Config{Framework: FrameworkEcho}, expected: FrameworkEcho},
+		{name: "gin flag and framework agree", config: Config{UseGin: true, Framework: FrameworkGin}, expected: FrameworkGin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.HTTPFramework(); got != tt.expected {
+				t.Errorf("HTTPFramework() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_SQLDatabase(t *testing.T) {
+	tests := []struct {
+		db       string
+		expected bool
+	}{
+		{db: "", expected: true},
+		{db: DBMySQL, expected: true},
+		{db: DBSQLite, expected: true},
+		{db: DBMongo, expected: false},
+	}
+
+	for _, tt := range tests {
+		config := Config{DB: tt.db}
+		if got := config.SQLDatabase(); got != tt.expected {
+			t.Errorf("Config{DB: %q}.SQLDatabase() = %v, want %v", tt.db, got, tt.expected)
+		}
+	}
+}
+
+func TestParseFlags_Framework(t *testing.T) {
+	path := writeSpec(t, "gogen.yaml", "module: github.com/acme/shop\nframework: echo\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "from spec", args: []string{"gogen", "--config", path}, expected: FrameworkEcho},
+		{name: "flag overrides spec", args: []string{"gogen", "--config", path, "--framework", "fiber"}, expected: FrameworkFiber},
+		{name: "gin flag overrides spec", args: []string{"gogen", "--config", path, "--gin"}, expected: FrameworkGin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			oldArgs := os.Args
+			os.Args = tt.args
+			defer func() { os.Args = oldArgs }()
+
+			config := ParseFlags()
+
+			if got := config.HTTPFramework(); got != tt.expected {
+				t.Errorf("HTTPFramework() = %q, want %q", got, tt.expected)
+			}
+			if err := config.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseFlags_ConfigOverrides(t *testing.T) {
+	path := writeSpec(t, "gogen.yaml", "module: github.com/acme/shop\narchitecture: monolith\nentities:\n  - name: product\n")
+
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	oldArgs := os.Args
+	os.Args = []string{"gogen", "--gin", "--config", path, "--entity", "order", "--entity", "product"}
+	defer func() { os.Args = oldArgs }()
+
+	config := ParseFlags()
+
+	if config.ModuleName != "github.com/acme/shop" {
+		t.Errorf("ModuleName = %v, want value from spec", config.ModuleName)
+	}
+	if !config.Monolith {
+		t.Error("Monolith = false, want value from spec")
+	}
+	if !config.UseGin {
+		t.Error("UseGin = false, want explicit flag to override spec")
+	}
+	if !reflect.DeepEqual(config.Entities, []string{"product", "order"}) {
+		t.Errorf("Entities = %v, want [product order]", config.Entities)
+	}
+}
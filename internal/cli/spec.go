@@ -0,0 +1,307 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/utils"
+)
+
+// projectSpec is the declarative project description read from gogen.yaml or gogen.json
+type projectSpec struct {
+	Module       string       `yaml:"module"`
+	Architecture string       `yaml:"architecture"`
+	Framework    string       `yaml:"framework"`
+	DB           string       `yaml:"db"`
+	Pgx          bool         `yaml:"pgx"`
+	SQLC         bool         `yaml:"sqlc"`
+	ORM          string       `yaml:"orm"`
+	Auth         bool         `yaml:"auth"`
+	GRPC         bool         `yaml:"grpc"`
+	Integration  bool         `yaml:"integration"`
+	Pagination   string       `yaml:"pagination"`
+	Entities     []specEntity `yaml:"entities"`
+	Components   []string     `yaml:"components"`
+}
+
+type specEntity struct {
+	Name      string         `yaml:"name"`
+	Fields    []specField    `yaml:"fields"`
+	Relations []specRelation `yaml:"relations"`
+}
+
+type specField struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Required bool   `yaml:"required"`
+	Unique   bool   `yaml:"unique"`
+}
+
+type specRelation struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Entity   string `yaml:"entity"`
+	Required bool   `yaml:"required"`
+}
+
+// SpecError reports a problem in a spec file together with its location
+type SpecError struct {
+	Path    string
+	Line    int
+	Message string
+}
+
+func (e *SpecError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// LoadSpec reads a YAML or JSON project spec and applies it to the configuration
+func (c *Config) LoadSpec(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	// JSON is a subset of YAML, so a single decoder handles both formats
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return yamlSpecError(path, err)
+	}
+
+	var spec projectSpec
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return yamlSpecError(path, err)
+	}
+
+	return c.applySpec(path, &root, &spec)
+}
+
+// applySpec validates the decoded spec and copies it into the configuration
+func (c *Config) applySpec(path string, root *yaml.Node, spec *projectSpec) error {
+	fail := func(message string, keys ...any) error {
+		return &SpecError{Path: path, Line: specLine(root, keys...), Message: message}
+	}
+
+	if strings.TrimSpace(spec.Module) == "" {
+		return fail("module is required")
+	}
+
+	switch spec.Architecture {
+	case "", "microservice":
+		c.Monolith = false
+	case "monolith":
+		c.Monolith = true
+	default:
+		return fail(fmt.Sprintf("unknown architecture %q (supported: microservice, monolith)", spec.Architecture), "architecture")
+	}
+
+	if !isKnownFramework(spec.Framework) {
+		return fail(fmt.Sprintf("unknown framework %q (supported: %s)", spec.Framework, strings.Join(KnownFrameworks, ", ")), "framework")
+	}
+	c.Framework = spec.Framework
+	c.UseGin = spec.Framework == FrameworkGin
+
+	if !isKnownDatabase(spec.DB) {
+		return fail(fmt.Sprintf("unknown database %q (supported: %s)", spec.DB, strings.Join(KnownDatabases, ", ")), "db")
+	}
+	if spec.Pgx && spec.DB != "" && spec.DB != DBPostgres {
+		return fail(fmt.Sprintf("pgx requires db: postgres, got %q", spec.DB), "pgx")
+	}
+	if spec.SQLC && spec.DB != "" && spec.DB != DBPostgres {
+		return fail(fmt.Sprintf("sqlc requires db: postgres, got %q", spec.DB), "sqlc")
+	}
+	if spec.Integration && spec.DB == DBMongo {
+		return fail(fmt.Sprintf("integration requires a SQL database, got %q", spec.DB), "integration")
+	}
+	if !isKnownORM(spec.ORM) {
+		return fail(fmt.Sprintf("unknown ORM %q (supported: %s)", spec.ORM, strings.Join(KnownORMs, ", ")), "orm")
+	}
+	if !isKnownPagination(spec.Pagination) {
+		return fail(fmt.Sprintf("unknown pagination %q (supported: %s)", spec.Pagination, strings.Join(KnownPaginations, ", ")), "pagination")
+	}
+	c.DB = spec.DB
+	c.UsePgx = spec.Pgx
+	c.UseSQLC = spec.SQLC
+	c.ORM = spec.ORM
+	c.Pagination = spec.Pagination
+
+	for i, name := range spec.Components {
+		if !isKnownComponent(name) {
+			return fail(fmt.Sprintf("unknown component %q (supported: %s)", name, strings.Join(knownComponents, ", ")), "components", i)
+		}
+	}
+
+	entities := make([]string, 0, len(spec.Entities))
+	fields := make(map[string]schema.Fields, len(spec.Entities))
+	for i, e := range spec.Entities {
+		key := utils.ToCamelCase(strings.TrimSpace(e.Name))
+		if key == "" {
+			return fail("entity name is required", "entities", i)
+		}
+		if _, exists := fields[key]; exists {
+			return fail(fmt.Sprintf("duplicate entity %q", e.Name), "entities", i, "name")
+		}
+		fields[key] = schema.Fields{}
+		entities = append(entities, e.Name)
+
+		for j, f := range e.Fields {
+			var modifiers []string
+			if f.Required {
+				modifiers = append(modifiers, "required")
+			}
+			if f.Unique {
+				modifiers = append(modifiers, "unique")
+			}
+			field, err := schema.NewField(f.Name, f.Type, modifiers...)
+			if err != nil {
+				return fail(fmt.Sprintf("entity %q: %v", e.Name, err), "entities", i, "fields", j)
+			}
+			if fields[key], err = fields[key].Add(field); err != nil {
+				return fail(fmt.Sprintf("entity %q: %v", e.Name, err), "entities", i, "fields", j, "name")
+			}
+		}
+	}
+
+	// Relations are resolved once every entity is known so they can point forward
+	for i, e := range spec.Entities {
+		owner := utils.ToCamelCase(strings.TrimSpace(e.Name))
+		for j, r := range e.Relations {
+			target := r.Entity
+			if target == "" {
+				target = r.Name
+			}
+			targetKey := utils.ToCamelCase(target)
+			if _, ok := fields[targetKey]; !ok {
+				return fail(fmt.Sprintf("entity %q: relation %q references undeclared entity %q", e.Name, r.Name, target), "entities", i, "relations", j)
+			}
+
+			// holder gets the column referencing the rows of referenced
+			var holder, referenced, column string
+			switch r.Type {
+			case "belongs_to":
+				holder, referenced, column = owner, targetKey, schema.ToSnakeCase(r.Name)+"_id"
+			case "has_many":
+				holder, referenced, column = targetKey, owner, schema.ToSnakeCase(owner)+"_id"
+			default:
+				return fail(fmt.Sprintf("entity %q: unknown relation type %q (supported: belongs_to, has_many)", e.Name, r.Type), "entities", i, "relations", j)
+			}
+			// every table is created after the tables it references
+			if holder == referenced || references(fields, referenced, holder) {
+				return fail(fmt.Sprintf("entity %q: relation %q makes %s reference itself; relations must not form a cycle", e.Name, r.Name, schema.TableName(holder)), "entities", i, "relations", j)
+			}
+			for _, field := range fields[holder] {
+				if field.GoName() == schema.ToPascalCase(column) {
+					return fail(fmt.Sprintf("entity %q: relation %q adds column %s.%s, which is already declared", e.Name, r.Name, schema.TableName(holder), column), "entities", i, "relations", j)
+				}
+			}
+			if !c.Monolith {
+				return fail(fmt.Sprintf("entity %q: relation %q requires architecture: monolith, where the related entities share one database", e.Name, r.Name), "entities", i, "relations", j)
+			}
+			// an optional relation gets a nullable column, like every optional uuid field
+			fields[holder] = append(fields[holder], schema.Field{
+				Name:       column,
+				Type:       "uuid",
+				Required:   r.Required,
+				References: referenced,
+			})
+		}
+	}
+
+	c.ModuleName = spec.Module
+	c.UseAuth = spec.Auth
+	c.UseGRPC = spec.GRPC
+	c.UseIntegration = spec.Integration
+	c.Entities = entities
+	c.Fields = nil
+	for key, entityFields := range fields {
+		if len(entityFields) == 0 {
+			continue
+		}
+		if c.Fields == nil {
+			c.Fields = make(map[string]schema.Fields)
+		}
+		c.Fields[key] = entityFields
+	}
+	if spec.Components != nil {
+		c.Components = spec.Components
+	}
+
+	return nil
+}
+
+// specLine finds the line of the node addressed by a path of mapping keys and sequence indexes
+func specLine(root *yaml.Node, keys ...any) int {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+
+	for _, key := range keys {
+		var next *yaml.Node
+		switch k := key.(type) {
+		case string:
+			if node.Kind != yaml.MappingNode {
+				return line
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == k {
+					next = node.Content[i+1]
+					line = node.Content[i].Line
+					break
+				}
+			}
+		case int:
+			if node.Kind != yaml.SequenceNode || k >= len(node.Content) {
+				return line
+			}
+			next = node.Content[k]
+			line = next.Line
+		}
+		if next == nil {
+			return line
+		}
+		node = next
+	}
+
+	return line
+}
+
+// yamlSpecError converts a YAML decoding error into a SpecError with the reported line
+func yamlSpecError(path string, err error) error {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		err = errors.New(typeErr.Errors[0])
+	}
+
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	var line int
+	if _, scanErr := fmt.Sscanf(message, "line %d:", &line); scanErr == nil {
+		message = strings.TrimSpace(message[strings.Index(message, ":")+1:])
+	}
+
+	return &SpecError{Path: path, Line: line, Message: message}
+}
+
+// references reports whether the rows of entity from point to those of entity to, directly or
+// through other entities
+func references(fields map[string]schema.Fields, from, to string) bool {
+	for _, field := range fields[from] {
+		if field.References == to || (field.References != "" && field.References != from && references(fields, field.References, to)) {
+			return true
+		}
+	}
+	return false
+}
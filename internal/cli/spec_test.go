@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/schema"
+)
+
+func TestConfig_LoadSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected Config
+	}{
+		{
+			name: "yaml spec",
+			file: "gogen.yaml",
+			content: `module: github.com/acme/shop
+architecture: monolith
+framework: gin
+auth: true
+grpc: true
+components: [docker]
+entities:
+  - name: customer
+    fields:
+      - name: email
+        type: string
+        required: true
+        unique: true
+  - name: order
+    fields:
+      - name: total
+        type: decimal
+    relations:
+      - name: customer
+        type: belongs_to
+      - name: coupon
+        type: belongs_to
+        required: true
+  - name: coupon
+`,
+			expected: Config{
+				ModuleName: "github.com/acme/shop",
+				Monolith:   true,
+				UseGin:     true,
+				UseAuth:    true,
+				Framework:  "gin",
+				UseGRPC:    true,
+				Entities:   []string{"customer", "order", "coupon"},
+				Fields: map[string]schema.Fields{
+					"customer": {{Name: "email", Type: "string", Required: true, Unique: true}},
+					"order": {
+						{Name: "total", Type: "decimal"},
+						{Name: "customer_id", Type: "uuid", References: "customer"},
+						{Name: "coupon_id", Type: "uuid", Required: true, References: "coupon"},
+					},
+				},
+				Components: []string{"docker"},
+			},
+		},
+		{
+			name: "json spec with has_many relation",
+			file: "gogen.json",
+			content: `{
+  "module": "github.com/acme/blog",
+  "architecture": "monolith",
+  "entities": [
+    {"name": "post", "relations": [{"name": "comments", "type": "has_many", "entity": "comment"}]},
+    {"name": "comment", "fields": [{"name": "body", "type": "text", "required": true}]}
+  ]
+}`,
+			expected: Config{
+				ModuleName: "github.com/acme/blog",
+				Monolith:   true,
+				Entities:   []string{"post", "comment"},
+				Fields: map[string]schema.Fields{
+					"comment": {
+						{Name: "body", Type: "text", Required: true},
+						{Name: "post_id", Type: "uuid", References: "post"},
+					},
+				},
+			},
+		},
+		{
+			name:    "database",
+			file:    "gogen.yaml",
+			content: "module: github.com/acme/shop\ndb: postgres\npgx: true\nsqlc: true\nintegration: true\n",
+			expected: Config{
+				ModuleName:     "github.com/acme/shop",
+				DB:             "postgres",
+				UsePgx:         true,
+				UseSQLC:        true,
+				UseIntegration: true,
+				Entities:       []string{},
+			},
+		},
+		{
+			name:    "cursor pagination",
+			file:    "gogen.yaml",
+			content: "module: github.com/acme/shop\npagination: cursor\n",
+			expected: Config{
+				ModuleName: "github.com/acme/shop",
+				Pagination: "cursor",
+				Entities:   []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSpec(t, tt.file, tt.content)
+
+			config := &Config{}
+			if err := config.LoadSpec(path); err != nil {
+				t.Fatalf("LoadSpec() error = %v", err)
+			}
+			if !reflect.DeepEqual(*config, tt.expected) {
+				t.Errorf("LoadSpec() = %+v, want %+v", *config, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadSpec_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		expectedLine int
+	}{
+		{
+			name:         "missing module",
+			content:      "architecture: monolith\n",
+			expectedLine: 1,
+		},
+		{
+			name:         "unknown framework",
+			content:      "module: github.com/acme/shop\nframework: martini\n",
+			expectedLine: 2,
+		},
+		{
+			name: "unsupported field type",
+			content: `module: github.com/acme/shop
+entities:
+  - name: product
+    fields:
+      - name: name
+        type: string
+      - name: price
+        type: money
+`,
+			expectedLine: 7,
+		},
+		{
+			name: "duplicate field",
+			content: `module: github.com/acme/shop
+entities:
+  - name: product
+    fields:
+      - name: name
+        type: string
+      - name: name
+        type: int
+`,
+			expectedLine: 7,
+		},
+		{
+			name: "fields with the same Go identifier",
+			content: `module: github.com/acme/shop
+entities:
+  - name: product
+    fields:
+      - name: owner_id
+        type: uuid
+      - name: owner_i_d
+        type: uuid
+`,
+			expectedLine: 7,
+		},
+		{
+			name: "relation to undeclared entity",
+			content: `module: github.com/acme/shop
+architecture: monolith
+entities:
+  - name: order
+    relations:
+      - name: customer
+        type: belongs_to
+`,
+			expectedLine: 6,
+		},
+		{
+			name: "relation to itself",
+			content: `module: github.com/acme/shop
+architecture: monolith
+entities:
+  - name: employee
+    relations:
+      - name: manager
+        type: belongs_to
+        entity: employee
+`,
+			expectedLine: 6,
+		},
+		{
+			name: "relations forming a cycle",
+			content: `module: github.com/acme/shop
+architecture: monolith
+entities:
+  - name: customer
+    relations:
+      - name: last_order
+        type: belongs_to
+        entity: order
+  - name: order
+    relations:
+      - name: customer
+        type: belongs_to
+`,
+			expectedLine: 11,
+		},
+		{
+			name: "relation declared on both sides",
+			content: `module: github.com/acme/shop
+architecture: monolith
+entities:
+  - name: customer
+    relations:
+      - name: orders
+        type: has_many
+        entity: order
+  - name: order
+    relations:
+      - name: customer
+        type: belongs_to
+`,
+			expectedLine: 11,
+		},
+		{
+			name: "relation in a microservice",
+			content: `module: github.com/acme/shop
+entities:
+  - name: customer
+  - name: order
+    relations:
+      - name: customer
+        type: belongs_to
+`,
+			expectedLine: 6,
+		},
+		{
+			name:         "unknown key",
+			content:      "module: github.com/acme/shop\nentitys: []\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "unknown database",
+			content:      "module: github.com/acme/shop\narchitecture: monolith\ndb: oracle\n",
+			expectedLine: 3,
+		},
+		{
+			name:         "pgx without postgres",
+			content:      "module: github.com/acme/shop\ndb: mysql\npgx: true\n",
+			expectedLine: 3,
+		},
+		{
+			name:         "sqlc without postgres",
+			content:      "module: github.com/acme/shop\ndb: sqlite\nsqlc: true\n",
+			expectedLine: 3,
+		},
+		{
+			name:         "integration with mongo",
+			content:      "module: github.com/acme/shop\ndb: mongo\nintegration: true\n",
+			expectedLine: 3,
+		},
+		{
+			name:         "unknown orm",
+			content:      "module: github.com/acme/shop\norm: ent\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "unknown pagination",
+			content:      "module: github.com/acme/shop\npagination: keyset\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "unknown component",
+			content:      "module: github.com/acme/shop\ncomponents:\n  - docker\n  - helm\n",
+			expectedLine: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSpec(t, "gogen.yaml", tt.content)
+
+			config := &Config{}
+			err := config.LoadSpec(path)
+			if err == nil {
+				t.Fatal("LoadSpec() expected error, got nil")
+			}
+
+			var specErr *SpecError
+			if !errors.As(err, &specErr) {
+				t.Fatalf("LoadSpec() error = %T, want *SpecError", err)
+			}
+			if specErr.Line != tt.expectedLine {
+				t.Errorf("error line = %d, want %d (%v)", specErr.Line, tt.expectedLine, err)
+			}
+		})
+	}
+}
+
+func TestConfig_HasComponent(t *testing.T) {
+	all := &Config{}
+	if !all.HasComponent(ComponentDocker) || !all.HasComponent(ComponentTaskfile) {
+		t.Error("HasComponent() = false for default configuration, want true")
+	}
+
+	dockerOnly := &Config{Components: []string{ComponentDocker}}
+	if !dockerOnly.HasComponent(ComponentDocker) {
+		t.Error("HasComponent(docker) = false, want true")
+	}
+	if dockerOnly.HasComponent(ComponentTaskfile) {
+		t.Error("HasComponent(taskfile) = true, want false")
+	}
+}
+
+// writeSpec writes a spec file into a temporary directory and returns its path
+func writeSpec(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write spec file: %v", err)
+	}
+	return path
+}
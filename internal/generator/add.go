@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/project"
+	"github.com/indalyadav56/gogen/internal/protoc"
+	"github.com/indalyadav56/gogen/internal/scaffold"
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/internal/sqlc"
+	"github.com/indalyadav56/gogen/internal/template"
+	"github.com/indalyadav56/gogen/utils"
+)
+
+// EntityAdder adds a bounded context to an existing gogen project
+type EntityAdder struct {
+	config      *cli.Config
+	renderer    *template.Renderer
+	projectRoot string
+}
+
+// NewEntityAdder detects the project at projectRoot and prepares an adder for it
+func NewEntityAdder(projectRoot string, templateFS embed.FS) (*EntityAdder, error) {
+	config, err := project.Detect(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntityAdder{
+		config:      config,
+		renderer:    template.NewRenderer(templateFS),
+		projectRoot: projectRoot,
+	}, nil
+}
+
+// Add creates internal/<entity>/..., wires the new bounded context into cmd/main.go and documents
+// it in docs/api/openapi.yaml
+func (ea *EntityAdder) Add(entityName string, fields schema.Fields) error {
+	if !ea.config.Monolith {
+		return fmt.Errorf("adding entities is only supported for monolith projects")
+	}
+
+	entityName = utils.ToCamelCase(entityName)
+	entityLower := strings.ToLower(entityName)
+	contextPath := filepath.Join(ea.projectRoot, "internal", entityLower)
+	if _, err := os.Stat(contextPath); err == nil {
+		return fmt.Errorf("bounded context %s already exists", contextPath)
+	}
+
+	// check the markers before writing anything, so a main.go without them leaves no half-added
+	// bounded context behind that a rerun would then refuse as already existing
+	mainPath := filepath.Join(ea.projectRoot, "cmd", "main.go")
+	if err := project.CheckMarkers(mainPath); err != nil {
+		if errors.Is(err, project.ErrNoMarkers) {
+			return fmt.Errorf("%w; restore them to wire new entities, nothing was generated", err)
+		}
+		return err
+	}
+
+	ea.config.Entities = append(ea.config.Entities, entityName)
+	if len(fields) > 0 {
+		ea.config.Fields = map[string]schema.Fields{entityName: fields}
+	}
+
+	dirStructure := &scaffold.DirectoryStructure{
+		ProjectRoot:  ea.projectRoot,
+		EntityName:   entityName,
+		IsMonolith:   true,
+		InfraPackage: scaffold.DatabaseFor(ea.config).Package,
+	}
+	if err := dirStructure.CreateDirectories(); err != nil {
+		return fmt.Errorf("failed to create directories for entity %s: %w", entityName, err)
+	}
+
+	fileGenerator := scaffold.NewFileGenerator(ea.renderer, ea.projectRoot, ea.config)
+	fileGenerator.Batch()
+	if err := fileGenerator.GenerateEntityFiles(entityName); err != nil {
+		return fmt.Errorf("failed to generate files for entity %s: %w", entityName, err)
+	}
+	if err := fileGenerator.Flush(); err != nil {
+		return fmt.Errorf("failed to generate files for entity %s: %w", entityName, err)
+	}
+
+	if err := ea.wireMain(fileGenerator, mainPath, entityName); err != nil {
+		return err
+	}
+
+	if err := fileGenerator.AddOpenAPIEntity(entityName); err != nil {
+		return err
+	}
+
+	if err := ea.generateProtoStubs(); err != nil {
+		return err
+	}
+
+	return ea.generateSQLC()
+}
+
+// generateProtoStubs recompiles the .proto files so the new entity's gRPC stubs exist
+func (ea *EntityAdder) generateProtoStubs() error {
+	files := scaffold.NewFileGenerator(ea.renderer, ea.projectRoot, ea.config).ProtoFiles()
+	if len(files) == 0 {
+		return nil
+	}
+
+	compiler := protoc.NewCompiler(ea.projectRoot)
+	if missing := compiler.Missing(); len(missing) > 0 {
+		fmt.Printf("⚠️  %s not found on PATH; run `task proto` (or protoc %s) to generate the gRPC stubs before building\n",
+			strings.Join(missing, ", "), strings.Join(protoc.Args(files), " "))
+		return nil
+	}
+	return compiler.Generate(files)
+}
+
+// generateSQLC regenerates the sqlc code so it includes the new entity's queries
+func (ea *EntityAdder) generateSQLC() error {
+	if !ea.config.UseSQLC {
+		return nil
+	}
+
+	generator := sqlc.NewGenerator(ea.projectRoot)
+	if !generator.Installed() {
+		fmt.Printf("⚠️  %s not found on PATH; run `task sqlc` (or sqlc generate) to generate the new queries before building\n", sqlc.Tool)
+		return nil
+	}
+	return generator.Generate()
+}
+
+// wireMain inserts the new bounded context into cmd/main.go, rendering the snippets with the data
+// of the entity's files so it is wired like the entities generated with the project
+func (ea *EntityAdder) wireMain(fileGenerator *scaffold.FileGenerator, mainPath, entityName string) error {
+	data := fileGenerator.TemplateData(entityName)
+
+	imports, err := ea.renderer.Render("templates/main_imports.tmpl", data)
+	if err != nil {
+		return err
+	}
+	wiring, err := ea.renderer.Render("templates/main_wiring.tmpl", data)
+	if err != nil {
+		return err
+	}
+
+	guard := fmt.Sprintf("%q", fmt.Sprintf("%s/internal/%s/application", ea.config.ModuleName, strings.ToLower(entityName)))
+	_, err = project.WireMain(mainPath, imports, wiring, guard)
+	return err
+}
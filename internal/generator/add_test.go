@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"embed"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goembed "github.com/indalyadav56/gogen"
+	"github.com/indalyadav56/gogen/internal/project"
+	"github.com/indalyadav56/gogen/internal/schema"
+)
+
+func TestNewEntityAdder_NotAProject(t *testing.T) {
+	var mockFS embed.FS
+
+	if _, err := NewEntityAdder(t.TempDir(), mockFS); err == nil {
+		t.Error("NewEntityAdder() expected error for empty directory, got nil")
+	}
+}
+
+func TestEntityAdder_Add_Microservice(t *testing.T) {
+	var mockFS embed.FS
+	root := t.TempDir()
+	createProjectLayout(t, root, "internal/domain/entity")
+
+	adder, err := NewEntityAdder(root, mockFS)
+	if err != nil {
+		t.Fatalf("NewEntityAdder() error = %v", err)
+	}
+
+	if err := adder.Add("order", nil); err == nil {
+		t.Error("Add() expected error for microservice project, got nil")
+	}
+}
+
+func TestEntityAdder_Add_ExistingContext(t *testing.T) {
+	var mockFS embed.FS
+	root := t.TempDir()
+	createProjectLayout(t, root, "internal/shared", "internal/product/domain")
+
+	adder, err := NewEntityAdder(root, mockFS)
+	if err != nil {
+		t.Fatalf("NewEntityAdder() error = %v", err)
+	}
+
+	if err := adder.Add("product", nil); err == nil {
+		t.Error("Add() expected error for existing bounded context, got nil")
+	}
+}
+
+func TestEntityAdder_Add_NoMarkers(t *testing.T) {
+	root := t.TempDir()
+	createProjectLayout(t, root, "internal/shared", "cmd")
+	writeProjectFile(t, root, "cmd/main.go", "package main\n\nfunc main() {}\n")
+
+	adder, err := NewEntityAdder(root, goembed.TemplateFS)
+	if err != nil {
+		t.Fatalf("NewEntityAdder() error = %v", err)
+	}
+
+	if err := adder.Add("order", nil); !errors.Is(err, project.ErrNoMarkers) {
+		t.Fatalf("Add() error = %v, want ErrNoMarkers", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "internal", "order")); !os.IsNotExist(err) {
+		t.Errorf("Add() created internal/order before failing on the markers (stat error = %v)", err)
+	}
+}
+
+func TestEntityAdder_Add_DetectedFeatures(t *testing.T) {
+	root := t.TempDir()
+	createProjectLayout(t, root, "internal/shared", "cmd", "test/integration", "pkg/query")
+	writeProjectFile(t, root, "cmd/main.go", "package main\n\nimport (\n\t// gogen:imports\n)\n\nfunc run() {\n\t// gogen:routes\n}\n")
+	writeProjectFile(t, root, "pkg/query/cursor.go", "package query\n")
+
+	adder, err := NewEntityAdder(root, goembed.TemplateFS)
+	if err != nil {
+		t.Fatalf("NewEntityAdder() error = %v", err)
+	}
+	fields := schema.Fields{{Name: "total", Type: "int", Required: true}}
+	if err := adder.Add("order", fields); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	mainGo, err := os.ReadFile(filepath.Join(root, "cmd", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(mainGo), `"github.com/test/project/internal/order/application"`) {
+		t.Errorf("cmd/main.go does not import the order service:\n%s", mainGo)
+	}
+	// the detected integration tests and cursor pagination carry over to the new entity
+	if _, err := os.Stat(filepath.Join(root, "test", "integration", "order_repository_test.go")); err != nil {
+		t.Errorf("missing the order integration test: %v", err)
+	}
+	spec, err := os.ReadFile(filepath.Join(root, "docs", "api", "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Add() wrote no OpenAPI spec: %v", err)
+	}
+	if !strings.Contains(string(spec), "/v1/order/{id}:") {
+		t.Errorf("OpenAPI spec does not document the order routes:\n%s", spec)
+	}
+	repository, err := os.ReadFile(filepath.Join(root, "internal", "order", "domain", "repository", "repository.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(repository), "FetchSize") {
+		t.Errorf("order repository is not cursor paginated:\n%s", repository)
+	}
+}
+
+// writeProjectFile writes content to the file at the slash-separated path under root
+func writeProjectFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, filepath.FromSlash(path)), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// createProjectLayout creates a minimal generated project with the given directories
+func createProjectLayout(t *testing.T, root string, dirs ...string) {
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/test/project\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+	}
+}
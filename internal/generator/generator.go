@@ -2,11 +2,17 @@ package generator
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/indalyadav56/gogen/internal/cli"
 	"github.com/indalyadav56/gogen/internal/gomod"
+	"github.com/indalyadav56/gogen/internal/protoc"
 	"github.com/indalyadav56/gogen/internal/scaffold"
+	"github.com/indalyadav56/gogen/internal/sqlc"
 	"github.com/indalyadav56/gogen/internal/template"
 	"github.com/indalyadav56/gogen/utils"
 )
@@ -16,6 +22,9 @@ type ProjectGenerator struct {
 	config     *cli.Config
 	renderer   *template.Renderer
 	gomodMgr   *gomod.Manager
+	protoc     *protoc.Compiler
+	sqlc       *sqlc.Generator
+	fileGenerator *scaffold.FileGenerator
 	projectRoot string
 }
 
@@ -23,26 +32,27 @@ type ProjectGenerator struct {
 func NewProjectGenerator(config *cli.Config, templateFS embed.FS) *ProjectGenerator {
 	projectRoot := config.GetProjectRoot()
 	
+	renderer := template.NewRenderer(templateFS)
+	
 	return &ProjectGenerator{
-		config:      config,
-		renderer:    template.NewRenderer(templateFS),
-		gomodMgr:    gomod.NewManager(projectRoot),
-		projectRoot: projectRoot,
+		config:        config,
+		renderer:      renderer,
+		gomodMgr:      gomod.NewManager(projectRoot),
+		protoc:        protoc.NewCompiler(projectRoot),
+		sqlc:          sqlc.NewGenerator(projectRoot),
+		fileGenerator: scaffold.NewFileGenerator(renderer, projectRoot, config),
+		projectRoot:   projectRoot,
 	}
 }
 
 // Generate generates the complete project structure
 func (pg *ProjectGenerator) Generate() error {
-	// For monolith architecture, create directories for each entity
-	if pg.config.Monolith && len(pg.config.Entities) > 0 {
-		for _, entityName := range pg.config.Entities {
-			if err := pg.createDirectoriesForEntity(utils.ToCamelCase(entityName)); err != nil {
-				return fmt.Errorf("failed to create directories for entity %s: %w", entityName, err)
+	// For monolith architecture, directories are created for each entity
+	for _, dirStructure := range pg.directoryStructures() {
+		if err := dirStructure.CreateDirectories(); err != nil {
+			if dirStructure.EntityName != "" {
+				return fmt.Errorf("failed to create directories for entity %s: %w", dirStructure.EntityName, err)
 			}
-		}
-	} else {
-		// Create base directory structure
-		if err := pg.createDirectories(); err != nil {
 			return fmt.Errorf("failed to create directories: %w", err)
 		}
 	}
@@ -52,57 +62,151 @@ func (pg *ProjectGenerator) Generate() error {
 		return fmt.Errorf("failed to generate files: %w", err)
 	}
 	
-	// Initialize Go module
-	if err := pg.gomodMgr.Init(pg.config.ModuleName); err != nil {
+	// Initialize Go module unless the project already has one
+	if _, err := os.Stat(filepath.Join(pg.projectRoot, "go.mod")); errors.Is(err, os.ErrNotExist) {
+		if err := pg.gomodMgr.Init(pg.config.ModuleName); err != nil {
+			return err
+		}
+	}
+	
+	// Compile the gRPC stubs; without protoc the imports stay unresolved until `task proto` runs
+	stubsGenerated, err := pg.generateProtoStubs()
+	if err != nil {
+		return err
+	}
+	
+	// Generate the sqlc code; without sqlc the repositories stay unresolved until `sqlc generate` runs
+	sqlcGenerated, err := pg.generateSQLC()
+	if err != nil {
 		return err
 	}
 	
 	// Run go mod tidy
-	if err := pg.gomodMgr.Tidy(); err != nil {
+	var tidyFlags []string
+	if !stubsGenerated || !sqlcGenerated {
+		tidyFlags = append(tidyFlags, "-e")
+	}
+	if err := pg.gomodMgr.Tidy(tidyFlags...); err != nil {
 		return err
 	}
 	
 	return nil
 }
 
-// createDirectories creates the project directory structure
-func (pg *ProjectGenerator) createDirectories() error {
-	dirStructure := &scaffold.DirectoryStructure{
-		ProjectRoot: pg.projectRoot,
-		EntityName:  "",
-		IsMonolith:  pg.config.Monolith,
+// Plan returns the directories and files Generate would create without touching the disk
+func (pg *ProjectGenerator) Plan() *scaffold.Plan {
+	plan := scaffold.NewPlan(pg.projectRoot)
+	
+	for _, dirStructure := range pg.directoryStructures() {
+		dirStructure.PlanDirectories(plan)
+	}
+	
+	for _, entityName := range pg.entityNames() {
+		pg.fileGenerator.PlanFiles(plan, entityName)
+	}
+	pg.fileGenerator.PlanOpenAPI(plan)
+	
+	for _, protoFile := range pg.fileGenerator.ProtoFiles() {
+		base := strings.TrimSuffix(protoFile, ".proto")
+		plan.AddFile(base+".pb.go", "", "protoc")
+		plan.AddFile(base+"_grpc.pb.go", "", "protoc")
+	}
+	
+	for _, sqlcFile := range pg.fileGenerator.SQLCOutputs() {
+		plan.AddFile(sqlcFile, "", "sqlc")
+	}
+	
+	if _, err := os.Stat(filepath.Join(pg.projectRoot, "go.mod")); errors.Is(err, os.ErrNotExist) {
+		plan.AddFile("go.mod", "", "go mod init")
 	}
 	
-	return dirStructure.CreateDirectories()
+	return plan
+}
+
+// Summary reports what happened to each generated file during the last Generate call
+func (pg *ProjectGenerator) Summary() scaffold.WriteSummary {
+	return pg.fileGenerator.Summary()
 }
 
-// createDirectoriesForEntity creates directories for a specific entity in monolith architecture
-func (pg *ProjectGenerator) createDirectoriesForEntity(entityName string) error {
-	dirStructure := &scaffold.DirectoryStructure{
-		ProjectRoot: pg.projectRoot,
-		EntityName:  entityName,
-		IsMonolith:  pg.config.Monolith,
+// directoryStructures returns the directory layouts to create, one per entity for monoliths
+func (pg *ProjectGenerator) directoryStructures() []*scaffold.DirectoryStructure {
+	if !pg.config.Monolith || len(pg.config.Entities) == 0 {
+		return []*scaffold.DirectoryStructure{{
+			ProjectRoot:  pg.projectRoot,
+			EntityName:   "",
+			IsMonolith:   pg.config.Monolith,
+			InfraPackage: scaffold.DatabaseFor(pg.config).Package,
+		}}
 	}
 	
-	return dirStructure.CreateDirectories()
+	structures := make([]*scaffold.DirectoryStructure, 0, len(pg.config.Entities))
+	for _, entityName := range pg.config.Entities {
+		structures = append(structures, &scaffold.DirectoryStructure{
+			ProjectRoot:  pg.projectRoot,
+			EntityName:   utils.ToCamelCase(entityName),
+			IsMonolith:   pg.config.Monolith,
+			InfraPackage: scaffold.DatabaseFor(pg.config).Package,
+		})
+	}
+	return structures
 }
 
-// generateFiles generates all project files
-func (pg *ProjectGenerator) generateFiles() error {
-	fileGenerator := scaffold.NewFileGenerator(pg.renderer, pg.projectRoot, pg.config.ModuleName, pg.config.Monolith, pg.config.UseGin, pg.config.UseAuth, pg.config.Entities)
-	
-	if len(pg.config.Entities) > 0 {
-		for _, entityName := range pg.config.Entities {
-			if err := fileGenerator.GenerateFiles(utils.ToCamelCase(entityName)); err != nil {
-				return err
-			}
-		}
-	} else {
+// entityNames returns the normalized entity names to generate files for
+func (pg *ProjectGenerator) entityNames() []string {
+	if len(pg.config.Entities) == 0 {
 		// Generate files without specific entity
-		if err := fileGenerator.GenerateFiles(""); err != nil {
+		return []string{""}
+	}
+	
+	names := make([]string, 0, len(pg.config.Entities))
+	for _, entityName := range pg.config.Entities {
+		names = append(names, utils.ToCamelCase(entityName))
+	}
+	return names
+}
+
+// generateFiles generates all project files; they are written together at the end, so conflicts
+// with existing files are all reported before any file changes
+func (pg *ProjectGenerator) generateFiles() error {
+	pg.fileGenerator.Batch()
+	for _, entityName := range pg.entityNames() {
+		if err := pg.fileGenerator.GenerateFiles(entityName); err != nil {
 			return err
 		}
 	}
 	
-	return nil
+	if err := pg.fileGenerator.GenerateOpenAPI(); err != nil {
+		return err
+	}
+	return pg.fileGenerator.Flush()
+}
+
+// generateProtoStubs compiles the generated .proto files and reports whether the stubs exist
+func (pg *ProjectGenerator) generateProtoStubs() (bool, error) {
+	files := pg.fileGenerator.ProtoFiles()
+	if len(files) == 0 {
+		return true, nil
+	}
+	
+	if missing := pg.protoc.Missing(); len(missing) > 0 {
+		fmt.Printf("⚠️  %s not found on PATH; run `task proto` (or protoc %s) to generate the gRPC stubs before building\n",
+			strings.Join(missing, ", "), strings.Join(protoc.Args(files), " "))
+		return false, nil
+	}
+	
+	return true, pg.protoc.Generate(files)
+}
+
+// generateSQLC runs sqlc when --sqlc is set and reports whether the sqlc code exists
+func (pg *ProjectGenerator) generateSQLC() (bool, error) {
+	if !pg.config.UseSQLC {
+		return true, nil
+	}
+	
+	if !pg.sqlc.Installed() {
+		fmt.Printf("⚠️  %s not found on PATH; run `task sqlc` (or sqlc generate) to generate the sqlc queries before building\n", sqlc.Tool)
+		return false, nil
+	}
+	
+	return true, pg.sqlc.Generate()
 }
@@ -2,6 +2,8 @@ package generator
 
 import (
 	"embed"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/indalyadav56/gogen/internal/cli"
@@ -130,3 +132,85 @@ func TestProjectGenerator_Generate_MultipleEntities(t *testing.T) {
 		t.Errorf("Expected 3 entities, got %d", len(config.Entities))
 	}
 }
+
+func TestProjectGenerator_Plan(t *testing.T) {
+	var mockFS embed.FS
+	config := &cli.Config{
+		ModuleName: "github.com/test/planned",
+		Entities:   []string{"user", "product"},
+		Monolith:   true,
+	}
+
+	generator := NewProjectGenerator(config, mockFS)
+	plan := generator.Plan()
+
+	paths := make(map[string]bool)
+	for _, entry := range plan.Entries() {
+		paths[entry.Path] = true
+	}
+
+	for _, expected := range []string{
+		"cmd/main.go",
+		"go.mod",
+		"internal/user/domain/entity/entity.go",
+		"internal/product/application/product_service.go",
+		"internal/product/interface/http/v1/dto",
+	} {
+		if !paths[expected] {
+			t.Errorf("Plan() missing %s", expected)
+		}
+	}
+
+	if _, err := os.Stat(plan.ProjectRoot); !os.IsNotExist(err) {
+		t.Errorf("Plan() touched the disk: %s exists", plan.ProjectRoot)
+	}
+}
+
+func TestProjectGenerator_Plan_ExistingGoMod(t *testing.T) {
+	var mockFS embed.FS
+	config := &cli.Config{
+		ModuleName: "github.com/test/planned",
+		Entities:   []string{"user"},
+	}
+
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(config.GetProjectRoot(), 0755); err != nil {
+		t.Fatalf("Failed to create project root: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(config.GetProjectRoot(), "go.mod"), []byte("module github.com/test/planned\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	for _, entry := range NewProjectGenerator(config, mockFS).Plan().Entries() {
+		if entry.Path == "go.mod" {
+			t.Errorf("Plan() lists go.mod (%s) although it already exists", entry.Note)
+		}
+	}
+}
+
+func TestProjectGenerator_Plan_GRPC(t *testing.T) {
+	var mockFS embed.FS
+	config := &cli.Config{
+		ModuleName: "github.com/test/planned",
+		Entities:   []string{"user"},
+		UseGRPC:    true,
+	}
+
+	plan := NewProjectGenerator(config, mockFS).Plan()
+
+	notes := make(map[string]string)
+	for _, entry := range plan.Entries() {
+		notes[entry.Path] = entry.Template + entry.Note
+	}
+
+	for path, expected := range map[string]string{
+		"api/proto/user/v1/user.proto":           "grpc_proto.tmpl",
+		"api/proto/user/v1/user.pb.go":           "protoc",
+		"api/proto/user/v1/user_grpc.pb.go":      "protoc",
+		"internal/interface/grpc/user_server.go": "grpc_server.tmpl",
+	} {
+		if notes[path] != expected {
+			t.Errorf("Plan() entry %s = %q, want %q", path, notes[path], expected)
+		}
+	}
+}
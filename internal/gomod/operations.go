@@ -32,9 +32,9 @@ func (m *Manager) Init(moduleName string) error {
 	return nil
 }
 
-// Tidy runs go mod tidy to clean up dependencies
-func (m *Manager) Tidy() error {
-	cmd := exec.Command("go", "mod", "tidy")
+// Tidy runs go mod tidy to clean up dependencies; flags such as -e are passed through
+func (m *Manager) Tidy(flags ...string) error {
+	cmd := exec.Command("go", append([]string{"mod", "tidy"}, flags...)...)
 	cmd.Dir = m.projectRoot
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
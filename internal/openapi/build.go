@@ -0,0 +1,331 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/utils"
+)
+
+// AuthPath is the base path of the auth routes in auth_routes.tmpl
+const AuthPath = "/api/v1/auth"
+
+// EntityPath returns the base path of an entity's CRUD routes; routes.tmpl and the other
+// frameworks' routes templates mount the routes at this path, so the spec and the router
+// cannot drift apart. Every framework but chi uses plural /api paths
+func EntityPath(entityName string, plural bool) string {
+	if plural {
+		return "/api/v1/" + strings.ToLower(entityName) + "s"
+	}
+	return "/v1/" + strings.ToLower(entityName)
+}
+
+// RoutePath returns the base path of an entity's routes, honoring paths imported from
+// an OpenAPI document over the EntityPath default
+func RoutePath(config *cli.Config, entityName string) string {
+	if path, ok := config.RoutePaths[entityName]; ok && path != "" {
+		return path
+	}
+	return EntityPath(entityName, config.HTTPFramework() != cli.FrameworkChi)
+}
+
+// Build describes the CRUD routes of every configured entity, and the auth routes when enabled
+func Build(config *cli.Config) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: config.ModuleName, Version: "1.0.0"},
+		Paths:   make(map[string]*PathItem),
+		Components: Components{Schemas: map[string]*Schema{
+			"Error": errorSchema(),
+		}},
+	}
+
+	for _, entityName := range config.Entities {
+		doc.AddEntity(config, entityName)
+	}
+
+	if config.UseAuth {
+		addAuth(doc)
+	}
+
+	return doc
+}
+
+// AddEntity adds the paths and schemas of a configured entity to the document, replacing those it
+// already had; the other paths and schemas are left as they are
+func (d *Document) AddEntity(config *cli.Config, entityName string) {
+	if d.Paths == nil {
+		d.Paths = make(map[string]*PathItem)
+	}
+	if d.Components.Schemas == nil {
+		d.Components.Schemas = make(map[string]*Schema)
+	}
+	if d.Components.Schemas["Error"] == nil {
+		d.Components.Schemas["Error"] = errorSchema()
+	}
+
+	entityName = utils.ToCamelCase(entityName)
+	addEntity(d, entityName, config.Fields[entityName], RoutePath(config, entityName), config.UseCursor())
+}
+
+// addEntity adds the paths and schemas of a single entity; cursor selects the keyset list parameters and page
+func addEntity(doc *Document, entityName string, fields schema.Fields, base string, cursor bool) {
+	name := strings.ToUpper(entityName[:1]) + entityName[1:]
+	tag := strings.ToLower(entityName)
+
+	doc.Components.Schemas["Create"+name+"Request"] = requestSchema(fields)
+	doc.Components.Schemas["Update"+name+"Request"] = requestSchema(fields)
+	doc.Components.Schemas[name+"Response"] = responseSchema(fields)
+	doc.Components.Schemas[name+"Page"] = pageSchema(name+"Response", cursor)
+
+	idParam := []Parameter{{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string", Format: "uuid"}}}
+	body := func(schemaName string) *RequestBody {
+		return &RequestBody{Required: true, Content: jsonContent(ref(schemaName))}
+	}
+
+	collection := &PathItem{
+		Get: &Operation{
+			OperationID: "list" + name + "s",
+			Summary:     "List " + tag + "s",
+			Tags:        []string{tag},
+			Parameters:  listParams(fields, cursor),
+			Responses: responses(map[string]Response{
+				"200": {Description: "OK", Content: jsonContent(ref(name + "Page"))},
+				"400": errorResponse("Invalid query"),
+			}),
+		},
+		Post: &Operation{
+			OperationID: "create" + name,
+			Summary:     "Create a " + tag,
+			Tags:        []string{tag},
+			RequestBody: body("Create" + name + "Request"),
+			Responses: responses(map[string]Response{
+				"201": {Description: "Created", Content: jsonContent(ref(name + "Response"))},
+				"400": errorResponse("Invalid request"),
+			}),
+		},
+	}
+	doc.Paths[base] = collection
+
+	doc.Paths[base+"/{id}"] = &PathItem{
+		Get: &Operation{
+			OperationID: "get" + name,
+			Summary:     "Get a " + tag + " by ID",
+			Tags:        []string{tag},
+			Parameters:  idParam,
+			Responses: responses(map[string]Response{
+				"200": {Description: "OK", Content: jsonContent(ref(name + "Response"))},
+				"404": errorResponse("Not found"),
+			}),
+		},
+		Put: &Operation{
+			OperationID: "update" + name,
+			Summary:     "Update a " + tag,
+			Tags:        []string{tag},
+			Parameters:  idParam,
+			RequestBody: body("Update" + name + "Request"),
+			Responses: responses(map[string]Response{
+				"200": {Description: "OK", Content: jsonContent(ref(name + "Response"))},
+				"400": errorResponse("Invalid request"),
+				"404": errorResponse("Not found"),
+			}),
+		},
+		Delete: &Operation{
+			OperationID: "delete" + name,
+			Summary:     "Delete a " + tag,
+			Tags:        []string{tag},
+			Parameters:  idParam,
+			Responses: responses(map[string]Response{
+				"204": {Description: "Deleted"},
+				"404": errorResponse("Not found"),
+			}),
+		},
+	}
+
+	// a unique value another record holds is rejected with 409
+	if fields.HasUnique() {
+		item := doc.Paths[base+"/{id}"]
+		collection.Post.Responses["409"] = errorResponse("Conflict")
+		item.Put.Responses["409"] = errorResponse("Conflict")
+	}
+}
+
+// addAuth adds the login, register and refresh routes of auth_routes.tmpl
+func addAuth(doc *Document) {
+	str := func() *Schema { return &Schema{Type: "string"} }
+
+	doc.Components.Schemas["LoginRequest"] = &Schema{
+		Type:     "object",
+		Required: []string{"email", "password"},
+		Properties: Properties{
+			{Name: "email", Schema: &Schema{Type: "string", Format: "email"}},
+			{Name: "password", Schema: str()},
+		},
+	}
+	doc.Components.Schemas["RegisterRequest"] = &Schema{
+		Type:     "object",
+		Required: []string{"email", "username", "password", "first_name", "last_name"},
+		Properties: Properties{
+			{Name: "email", Schema: &Schema{Type: "string", Format: "email"}},
+			{Name: "username", Schema: str()},
+			{Name: "password", Schema: str()},
+			{Name: "first_name", Schema: str()},
+			{Name: "last_name", Schema: str()},
+		},
+	}
+	doc.Components.Schemas["RefreshTokenRequest"] = &Schema{
+		Type:       "object",
+		Required:   []string{"refresh_token"},
+		Properties: Properties{{Name: "refresh_token", Schema: str()}},
+	}
+	doc.Components.Schemas["AuthResponse"] = &Schema{
+		Type: "object",
+		Properties: Properties{
+			{Name: "message", Schema: str()},
+			{Name: "data", Schema: &Schema{
+				Type: "object",
+				Properties: Properties{
+					{Name: "user", Schema: &Schema{Type: "object"}},
+					{Name: "access_token", Schema: str()},
+					{Name: "refresh_token", Schema: str()},
+					{Name: "expires_at", Schema: &Schema{Type: "string", Format: "date-time"}},
+				},
+			}},
+		},
+	}
+
+	operation := func(id, summary, request, status string) *PathItem {
+		return &PathItem{Post: &Operation{
+			OperationID: id,
+			Summary:     summary,
+			Tags:        []string{"auth"},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref(request))},
+			Responses: responses(map[string]Response{
+				status: {Description: "OK", Content: jsonContent(ref("AuthResponse"))},
+				"400":  errorResponse("Invalid request"),
+			}),
+		}}
+	}
+
+	doc.Paths[AuthPath+"/login"] = operation("login", "Log in with email and password", "LoginRequest", "200")
+	doc.Paths[AuthPath+"/register"] = operation("register", "Register a new user", "RegisterRequest", "201")
+	doc.Paths[AuthPath+"/refresh"] = operation("refreshToken", "Exchange a refresh token for new tokens", "RefreshTokenRequest", "200")
+}
+
+// requestSchema describes a create or update request DTO
+func requestSchema(fields schema.Fields) *Schema {
+	s := &Schema{Type: "object"}
+	for _, field := range fields {
+		s.Properties = append(s.Properties, Property{Name: field.JSONName(), Schema: fieldSchema(field)})
+		if field.Required {
+			s.Required = append(s.Required, field.JSONName())
+		}
+	}
+	return s
+}
+
+// responseSchema describes an entity response DTO
+func responseSchema(fields schema.Fields) *Schema {
+	s := &Schema{
+		Type:       "object",
+		Required:   []string{"id", "created_at", "updated_at"},
+		Properties: Properties{{Name: "id", Schema: &Schema{Type: "string", Format: "uuid", ReadOnly: true}}},
+	}
+	for _, field := range fields {
+		s.Properties = append(s.Properties, Property{Name: field.JSONName(), Schema: fieldSchema(field)})
+	}
+	s.Properties = append(s.Properties,
+		Property{Name: "created_at", Schema: &Schema{Type: "string", Format: "date-time", ReadOnly: true}},
+		Property{Name: "updated_at", Schema: &Schema{Type: "string", Format: "date-time", ReadOnly: true}},
+	)
+	return s
+}
+
+// listParams describes the page (or cursor), page_size, sort and filter[field] query parameters
+// parsed by the generated pkg/query package; fields can be sorted and filtered by column name
+func listParams(fields schema.Fields, cursor bool) []Parameter {
+	columns := []Property{{Name: "id", Schema: &Schema{Type: "string", Format: "uuid"}}}
+	sortValues := []string{"id", "-id"}
+	for _, field := range fields {
+		// Filters match values, never NULL
+		filter := fieldSchema(field)
+		filter.Nullable = false
+		columns = append(columns, Property{Name: field.Column(), Schema: filter})
+		// Lists cannot be sorted by nullable columns
+		if !field.Nullable() {
+			sortValues = append(sortValues, field.Column(), "-"+field.Column())
+		}
+	}
+	columns = append(columns,
+		Property{Name: "created_at", Schema: &Schema{Type: "string", Format: "date-time"}},
+		Property{Name: "updated_at", Schema: &Schema{Type: "string", Format: "date-time"}},
+	)
+	sortValues = append(sortValues, "created_at", "-created_at", "updated_at", "-updated_at")
+
+	one, maxPageSize := 1, 100
+	position := Parameter{Name: "page", In: "query", Schema: &Schema{Type: "integer", Minimum: &one}}
+	if cursor {
+		position = Parameter{Name: "cursor", In: "query", Description: "next_cursor or prev_cursor of a page listed with the same sort; omitted for the first page", Schema: &Schema{Type: "string"}}
+	}
+	return []Parameter{
+		position,
+		{Name: "page_size", In: "query", Description: "Defaults to 20", Schema: &Schema{Type: "integer", Minimum: &one, Maximum: &maxPageSize}},
+		{Name: "sort", In: "query", Description: "Field to sort by, prefixed with - for descending order; defaults to created_at", Schema: &Schema{Type: "string", Enum: sortValues}},
+		{Name: "filter", In: "query", Description: "Field values to match, e.g. filter[id]=...", Style: "deepObject", Explode: true, Schema: &Schema{Type: "object", Properties: columns}},
+	}
+}
+
+// pageSchema describes the query.Page envelope of a list response holding items of the named
+// schema; a cursor page carries the tokens of its neighbours instead of page numbers and totals
+func pageSchema(items string, cursor bool) *Schema {
+	integer := func() *Schema { return &Schema{Type: "integer"} }
+	if cursor {
+		return &Schema{
+			Type:     "object",
+			Required: []string{"items", "page_size"},
+			Properties: Properties{
+				{Name: "items", Schema: &Schema{Type: "array", Items: ref(items)}},
+				{Name: "page_size", Schema: integer()},
+				{Name: "next_cursor", Schema: &Schema{Type: "string"}},
+				{Name: "prev_cursor", Schema: &Schema{Type: "string"}},
+			},
+		}
+	}
+	return &Schema{
+		Type:     "object",
+		Required: []string{"items", "page", "page_size", "total", "total_pages"},
+		Properties: Properties{
+			{Name: "items", Schema: &Schema{Type: "array", Items: ref(items)}},
+			{Name: "page", Schema: integer()},
+			{Name: "page_size", Schema: integer()},
+			{Name: "total", Schema: &Schema{Type: "integer", Format: "int64"}},
+			{Name: "total_pages", Schema: integer()},
+		},
+	}
+}
+
+// fieldSchema returns the schema of a single entity field
+func fieldSchema(field schema.Field) *Schema {
+	typ, format := field.OpenAPIType()
+	return &Schema{Type: typ, Format: format, Nullable: field.Nullable()}
+}
+
+// responses adds the internal server error response shared by every entity operation
+func responses(r map[string]Response) map[string]Response {
+	r["500"] = errorResponse("Internal server error")
+	return r
+}
+
+// errorResponse describes the {"error": "..."} body written by the handlers
+func errorResponse(description string) Response {
+	return Response{Description: description, Content: jsonContent(ref("Error"))}
+}
+
+// errorSchema returns the Error schema every error response refers to
+func errorSchema() *Schema {
+	return &Schema{
+		Type:       "object",
+		Properties: Properties{{Name: "error", Schema: &Schema{Type: "string"}}},
+	}
+}
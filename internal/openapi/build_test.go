@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/schema"
+)
+
+func TestEntityPath(t *testing.T) {
+	tests := []struct {
+		entity   string
+		useGin   bool
+		expected string
+	}{
+		{"product", false, "/v1/product"},
+		{"product", true, "/api/v1/products"},
+		{"orderItem", false, "/v1/orderitem"},
+		{"orderItem", true, "/api/v1/orderitems"},
+	}
+
+	for _, tt := range tests {
+		if got := EntityPath(tt.entity, tt.useGin); got != tt.expected {
+			t.Errorf("EntityPath(%q, %v) = %q, want %q", tt.entity, tt.useGin, got, tt.expected)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	fields := schema.Fields{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "price", Type: "decimal"},
+	}
+
+	tests := []struct {
+		name         string
+		config       *cli.Config
+		paths        []string
+		missingPaths []string
+	}{
+		{
+			name: "chi",
+			config: &cli.Config{
+				ModuleName: "example.com/shop",
+				Entities:   []string{"product"},
+				Fields:     map[string]schema.Fields{"product": fields},
+			},
+			paths:        []string{"/v1/product", "/v1/product/{id}"},
+			missingPaths: []string{AuthPath + "/login"},
+		},
+		{
+			name: "gin with auth",
+			config: &cli.Config{
+				ModuleName: "example.com/shop",
+				Entities:   []string{"product"},
+				Fields:     map[string]schema.Fields{"product": fields},
+				UseGin:     true,
+				UseAuth:    true,
+			},
+			paths: []string{"/api/v1/products", "/api/v1/products/{id}", AuthPath + "/login", AuthPath + "/register", AuthPath + "/refresh"},
+		},
+		{
+			name: "echo",
+			config: &cli.Config{
+				ModuleName: "example.com/shop",
+				Entities:   []string{"product"},
+				Fields:     map[string]schema.Fields{"product": fields},
+				Framework:  cli.FrameworkEcho,
+			},
+			paths: []string{"/api/v1/products", "/api/v1/products/{id}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Build(tt.config)
+
+			for _, path := range tt.paths {
+				if doc.Paths[path] == nil {
+					t.Errorf("missing path %s", path)
+				}
+			}
+			for _, path := range tt.missingPaths {
+				if doc.Paths[path] != nil {
+					t.Errorf("unexpected path %s", path)
+				}
+			}
+
+			collection := doc.Paths[RoutePath(tt.config, "product")]
+			if collection == nil || collection.Post == nil {
+				t.Fatal("missing create operation")
+			}
+			if collection.Get == nil || collection.Get.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/ProductPage" {
+				t.Error("list operation should respond with a ProductPage")
+			}
+
+			item := doc.Paths[RoutePath(tt.config, "product")+"/{id}"]
+			if item.Get == nil || item.Put == nil || item.Delete == nil {
+				t.Error("missing get, update or delete operation")
+			}
+			if _, ok := item.Delete.Responses["204"]; !ok {
+				t.Error("delete operation should respond with 204")
+			}
+
+			request := doc.Components.Schemas["CreateProductRequest"]
+			if request == nil {
+				t.Fatal("missing CreateProductRequest schema")
+			}
+			if strings.Join(request.Required, ",") != "name" {
+				t.Errorf("required = %v, want [name]", request.Required)
+			}
+
+			response := doc.Components.Schemas["ProductResponse"]
+			var names []string
+			for _, property := range response.Properties {
+				names = append(names, property.Name)
+			}
+			if got := strings.Join(names, ","); got != "id,name,price,created_at,updated_at" {
+				t.Errorf("response properties = %s", got)
+			}
+			if price := response.Properties[2].Schema; price.Type != "number" || price.Format != "decimal" {
+				t.Errorf("price schema = %s/%s, want number/decimal", price.Type, price.Format)
+			}
+		})
+	}
+}
+
+func TestBuild_ConflictResponses(t *testing.T) {
+	config := &cli.Config{
+		ModuleName: "example.com/shop",
+		Entities:   []string{"product", "tag"},
+		Fields: map[string]schema.Fields{
+			"product": {{Name: "sku", Type: "string", Required: true, Unique: true}},
+			"tag":     {{Name: "label", Type: "string"}},
+		},
+	}
+	doc := Build(config)
+
+	for entity, want := range map[string]bool{"product": true, "tag": false} {
+		base := RoutePath(config, entity)
+		if _, got := doc.Paths[base].Post.Responses["409"]; got != want {
+			t.Errorf("%s create has a 409 response = %v, want %v", entity, got, want)
+		}
+		if _, got := doc.Paths[base+"/{id}"].Put.Responses["409"]; got != want {
+			t.Errorf("%s update has a 409 response = %v, want %v", entity, got, want)
+		}
+	}
+}
+
+func TestDocument_AddEntity(t *testing.T) {
+	config := &cli.Config{
+		ModuleName: "example.com/shop",
+		Entities:   []string{"product"},
+		Fields:     map[string]schema.Fields{"product": {{Name: "name", Type: "string", Required: true}}},
+	}
+	doc := Build(config)
+	// what the spec documents for existing entities survives, even what their detected fields
+	// would not rebuild, such as the conflict response of a unique field
+	doc.Paths["/v1/product"].Post.Responses["409"] = errorResponse("Conflict")
+
+	config.Entities = append(config.Entities, "order")
+	config.Fields["order"] = schema.Fields{{Name: "quantity", Type: "int", Required: true}}
+	doc.AddEntity(config, "order")
+
+	for _, path := range []string{"/v1/product/{id}", "/v1/order", "/v1/order/{id}"} {
+		if doc.Paths[path] == nil {
+			t.Errorf("missing path %s", path)
+		}
+	}
+	if _, ok := doc.Paths["/v1/product"].Post.Responses["409"]; !ok {
+		t.Error("AddEntity() dropped the 409 response of the product create operation")
+	}
+	if request := doc.Components.Schemas["CreateOrderRequest"]; request == nil || len(request.Properties) != 1 {
+		t.Errorf("CreateOrderRequest = %+v, want the quantity property", request)
+	}
+
+	empty := &Document{OpenAPI: "3.0.3"}
+	empty.AddEntity(config, "product")
+	if empty.Paths["/v1/product"] == nil || empty.Components.Schemas["Error"] == nil {
+		t.Error("AddEntity() to an empty document left out the product path or the Error schema")
+	}
+}
+
+func TestBuild_ListParams(t *testing.T) {
+	doc := Build(&cli.Config{
+		ModuleName: "example.com/shop",
+		Entities:   []string{"product"},
+		Fields: map[string]schema.Fields{"product": {
+			{Name: "released_at", Type: "time", Required: true},
+			{Name: "shipped_at", Type: "time"},
+		}},
+	})
+
+	params := make(map[string]Parameter)
+	for _, param := range doc.Paths[EntityPath("product", false)].Get.Parameters {
+		params[param.Name] = param
+	}
+	for _, name := range []string{"page", "page_size", "sort", "filter"} {
+		if params[name].In != "query" {
+			t.Errorf("missing query parameter %s", name)
+		}
+	}
+
+	if got := strings.Join(params["sort"].Schema.Enum, ","); got != "id,-id,released_at,-released_at,created_at,-created_at,updated_at,-updated_at" {
+		t.Errorf("sort values = %s", got)
+	}
+
+	filter := params["filter"]
+	if filter.Style != "deepObject" || !filter.Explode {
+		t.Errorf("filter style = %q (explode %v), want deepObject (explode true)", filter.Style, filter.Explode)
+	}
+	if released := filter.Schema.Properties[1]; released.Name != "released_at" || released.Schema.Format != "date-time" {
+		t.Errorf("filter property = %s (%s), want released_at (date-time)", released.Name, released.Schema.Format)
+	}
+	if shipped := filter.Schema.Properties[2]; shipped.Name != "shipped_at" || shipped.Schema.Nullable {
+		t.Errorf("filter property = %s (nullable %v), want shipped_at (not nullable)", shipped.Name, shipped.Schema.Nullable)
+	}
+	if shipped := doc.Components.Schemas["ProductResponse"].Properties[2].Schema; !shipped.Nullable {
+		t.Error("optional time shipped_at should be nullable in ProductResponse")
+	}
+
+	page := doc.Components.Schemas["ProductPage"]
+	if page == nil || page.Properties[0].Name != "items" || page.Properties[0].Schema.Items.Ref != "#/components/schemas/ProductResponse" {
+		t.Error("ProductPage should hold ProductResponse items")
+	}
+}
+
+func TestBuild_CursorPagination(t *testing.T) {
+	doc := Build(&cli.Config{
+		ModuleName: "example.com/shop",
+		Entities:   []string{"product"},
+		Pagination: cli.PaginationCursor,
+	})
+
+	var names []string
+	for _, param := range doc.Paths[EntityPath("product", false)].Get.Parameters {
+		names = append(names, param.Name)
+	}
+	if got := strings.Join(names, ","); got != "cursor,page_size,sort,filter" {
+		t.Errorf("list parameters = %s, want cursor,page_size,sort,filter", got)
+	}
+
+	var properties []string
+	for _, property := range doc.Components.Schemas["ProductPage"].Properties {
+		properties = append(properties, property.Name)
+	}
+	if got := strings.Join(properties, ","); got != "items,page_size,next_cursor,prev_cursor" {
+		t.Errorf("ProductPage properties = %s, want items,page_size,next_cursor,prev_cursor", got)
+	}
+}
@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the subset of an OpenAPI 3 document that gogen writes and reads
+type Document struct {
+	OpenAPI    string               `yaml:"openapi"`
+	Info       Info                 `yaml:"info"`
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components Components           `yaml:"components,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// PathItem holds the operations available on a single path
+type PathItem struct {
+	Get    *Operation `yaml:"get,omitempty"`
+	Post   *Operation `yaml:"post,omitempty"`
+	Put    *Operation `yaml:"put,omitempty"`
+	Patch  *Operation `yaml:"patch,omitempty"`
+	Delete *Operation `yaml:"delete,omitempty"`
+}
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	OperationID string              `yaml:"operationId,omitempty"`
+	Summary     string              `yaml:"summary,omitempty"`
+	Tags        []string            `yaml:"tags,omitempty"`
+	Parameters  []Parameter         `yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// Parameter describes a path or query parameter
+type Parameter struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+	// Style and Explode serialize object parameters, e.g. deepObject for filter[field]=value
+	Style   string  `yaml:"style,omitempty"`
+	Explode bool    `yaml:"explode,omitempty"`
+	Schema  *Schema `yaml:"schema,omitempty"`
+}
+
+// RequestBody describes the payload of an operation
+type RequestBody struct {
+	Required bool                 `yaml:"required,omitempty"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response describes a single response of an operation
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request or response body
+type MediaType struct {
+	Schema *Schema `yaml:"schema,omitempty"`
+}
+
+// Components holds the reusable schemas of the document
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas,omitempty"`
+}
+
+// Schema is a JSON schema object or a reference to one
+type Schema struct {
+	Ref        string     `yaml:"$ref,omitempty"`
+	Type       string     `yaml:"type,omitempty"`
+	Format     string     `yaml:"format,omitempty"`
+	Nullable   bool       `yaml:"nullable,omitempty"`
+	Required   []string   `yaml:"required,omitempty"`
+	Properties Properties `yaml:"properties,omitempty"`
+	Items      *Schema    `yaml:"items,omitempty"`
+	Enum       []string   `yaml:"enum,omitempty"`
+	Minimum    *int       `yaml:"minimum,omitempty"`
+	Maximum    *int       `yaml:"maximum,omitempty"`
+	ReadOnly   bool       `yaml:"readOnly,omitempty"`
+}
+
+// Property is a named schema property
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties keeps schema properties in declaration order
+type Properties []Property
+
+// MarshalYAML encodes the properties as a mapping in declaration order
+func (p Properties) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, property := range p {
+		value := &yaml.Node{}
+		if err := value.Encode(property.Schema); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: property.Name}, value)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML decodes a mapping of properties, keeping the document order
+func (p *Properties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var schema Schema
+		if err := node.Content[i+1].Decode(&schema); err != nil {
+			return err
+		}
+		*p = append(*p, Property{Name: node.Content[i].Value, Schema: &schema})
+	}
+	return nil
+}
+
+// YAML encodes the document with the two-space indentation customary for OpenAPI files
+func (d *Document) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(d); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ref returns a reference to a component schema
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// jsonContent wraps a schema as an application/json body
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestProperties_YAML(t *testing.T) {
+	s := &Schema{
+		Type: "object",
+		Properties: Properties{
+			{Name: "zeta", Schema: &Schema{Type: "string"}},
+			{Name: "alpha", Schema: &Schema{Type: "integer", Format: "int64"}},
+		},
+	}
+
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Index(string(out), "zeta") > strings.Index(string(out), "alpha") {
+		t.Errorf("properties out of declaration order:\n%s", out)
+	}
+
+	var decoded Schema
+	if err := yaml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(decoded.Properties) != 2 || decoded.Properties[0].Name != "zeta" || decoded.Properties[1].Schema.Format != "int64" {
+		t.Errorf("decoded properties = %+v", decoded.Properties)
+	}
+}
+
+func TestDocument_YAML(t *testing.T) {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: "example", Version: "1.0.0"},
+		Paths: map[string]*PathItem{
+			"/v1/product": {Post: &Operation{OperationID: "createProduct", Responses: map[string]Response{"201": {Description: "Created"}}}},
+		},
+		Components: Components{Schemas: map[string]*Schema{"Product": {Ref: "#/components/schemas/Other"}}},
+	}
+
+	out, err := doc.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	for _, want := range []string{"openapi: 3.0.3", "operationId: createProduct", "$ref: '#/components/schemas/Other'"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("YAML() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// versionSegment matches API version path segments such as v1
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// Entity is a resource recovered from an OpenAPI document
+type Entity struct {
+	// Name is the kebab-case entity name, as accepted by --entity
+	Name string
+	// BasePath is the collection path the contract mounts the resource at
+	BasePath string
+	Fields   schema.Fields
+}
+
+// resource collects the operations of one collection path and its item path
+type resource struct {
+	segment   string
+	basePath  string
+	requests  []namedSchema
+	responses []namedSchema
+}
+
+// namedSchema is a schema together with the component name it was referenced by
+type namedSchema struct {
+	name   string
+	schema *Schema
+	create bool
+}
+
+// Load reads an OpenAPI 3 document from a YAML or JSON file
+func Load(path string) (*Document, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+
+	var doc Document
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s: unsupported OpenAPI version %q (expected 3.x)", path, doc.OpenAPI)
+	}
+
+	return &doc, nil
+}
+
+// Apply adds the entities of the OpenAPI document at path to the configuration and returns
+// warnings for everything that was skipped; entities already declared with --entity keep
+// the fields given on the command line
+func Apply(config *cli.Config, path string) ([]string, error) {
+	doc, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, warnings := doc.Entities()
+	if len(entities) == 0 {
+		return warnings, fmt.Errorf("%s: no resource paths found", path)
+	}
+
+	if config.Fields == nil {
+		config.Fields = make(map[string]schema.Fields)
+	}
+	if config.RoutePaths == nil {
+		config.RoutePaths = make(map[string]string)
+	}
+
+	for _, entity := range entities {
+		key := utils.ToCamelCase(entity.Name)
+		declared := false
+		for _, entityName := range config.Entities {
+			if utils.ToCamelCase(entityName) == key {
+				declared = true
+			}
+		}
+		if !declared {
+			config.Entities = append(config.Entities, entity.Name)
+		}
+		if len(config.Fields[key]) == 0 && len(entity.Fields) > 0 {
+			config.Fields[key] = entity.Fields
+		}
+		config.RoutePaths[key] = entity.BasePath
+	}
+
+	return warnings, nil
+}
+
+// Entities maps the document's resource paths onto entities; operations and properties
+// that cannot be expressed as entity CRUD are reported as warnings
+func (d *Document) Entities() ([]Entity, []string) {
+	var warnings []string
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var order []string
+	resources := make(map[string]*resource)
+
+	for _, path := range paths {
+		segments, basePath := resourceSegments(path)
+		switch {
+		case len(segments) == 0:
+			warn("%s: no resource segment, skipped", path)
+			continue
+		case segments[0] == "auth":
+			warn("%s: auth routes are generated with --auth, skipped", path)
+			continue
+		case len(segments) > 1:
+			warn("%s: nested and action routes are not supported, skipped", path)
+			continue
+		}
+
+		key := schema.Singular(schema.ToSnakeCase(segments[0]))
+		res, ok := resources[key]
+		if !ok {
+			res = &resource{segment: key, basePath: basePath}
+			resources[key] = res
+			order = append(order, key)
+		}
+
+		isItem := strings.HasSuffix(path, "}")
+		for _, op := range d.Paths[path].operations() {
+			if op.method != "get" && op.op.RequestBody != nil {
+				if s := d.jsonSchema(op.op.RequestBody.Content); s.schema != nil {
+					s.create = op.method == "post"
+					res.requests = append(res.requests, s)
+				}
+			}
+			if op.method == "delete" {
+				continue
+			}
+			for _, status := range []string{"200", "201"} {
+				response, ok := op.op.Responses[status]
+				if !ok {
+					continue
+				}
+				s := d.jsonSchema(response.Content)
+				if op.method == "get" && !isItem {
+					s = d.listItems(s)
+				}
+				if s.schema != nil {
+					res.responses = append(res.responses, s)
+				}
+			}
+		}
+	}
+
+	entities := make([]Entity, 0, len(order))
+	for _, key := range order {
+		res := resources[key]
+		entity := Entity{Name: res.entityName(), BasePath: res.basePath}
+		entity.Fields = d.fields(res, warn)
+		if len(res.requests) == 0 && len(res.responses) == 0 {
+			warn("%s: no request or response schema, generated without fields", res.basePath)
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, warnings
+}
+
+// operation pairs an operation with its HTTP method
+type operation struct {
+	method string
+	op     *Operation
+}
+
+// operations returns the operations of a path in a stable order
+func (p *PathItem) operations() []operation {
+	var ops []operation
+	for _, op := range []operation{{"post", p.Post}, {"get", p.Get}, {"put", p.Put}, {"patch", p.Patch}, {"delete", p.Delete}} {
+		if op.op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// resourceSegments returns the non-parameter segments of a path after any api/version
+// prefix, along with the collection path up to and including the first such segment
+func resourceSegments(path string) ([]string, string) {
+	var segments []string
+	var base []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		switch {
+		case segment == "":
+			continue
+		case len(segments) == 0 && (segment == "api" || versionSegment.MatchString(segment)):
+			base = append(base, segment)
+		case strings.HasPrefix(segment, "{"):
+			continue
+		default:
+			if len(segments) == 0 {
+				base = append(base, segment)
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return segments, "/" + strings.Join(base, "/")
+}
+
+// entityName prefers the casing of a component schema named after the resource,
+// e.g. CreateOrderItemRequest for /order_items, over the path segment
+func (r *resource) entityName() string {
+	for _, s := range append(append([]namedSchema{}, r.requests...), r.responses...) {
+		base := s.name
+		for _, prefix := range []string{"Create", "Update", "New"} {
+			base = strings.TrimPrefix(base, prefix)
+		}
+		for _, suffix := range []string{"Request", "Response", "Input", "Output", "DTO", "Dto"} {
+			base = strings.TrimSuffix(base, suffix)
+		}
+		if base != "" && schema.ToSnakeCase(base) == r.segment {
+			return strings.ReplaceAll(schema.ToSnakeCase(base), "_", "-")
+		}
+	}
+	return strings.ReplaceAll(r.segment, "_", "-")
+}
+
+// fields merges the properties of a resource's request and response schemas; create
+// request properties come first and decide which fields are required
+func (d *Document) fields(res *resource, warn func(string, ...any)) schema.Fields {
+	schemas := make([]namedSchema, 0, len(res.requests)+len(res.responses))
+	required := map[string]bool{}
+	for _, s := range res.requests {
+		if s.create {
+			schemas = append(schemas, s)
+			for _, name := range s.schema.Required {
+				required[name] = true
+			}
+		}
+	}
+	for _, s := range res.requests {
+		if !s.create {
+			schemas = append(schemas, s)
+		}
+	}
+	schemas = append(schemas, res.responses...)
+	if len(required) == 0 && len(schemas) > 0 {
+		for _, name := range schemas[0].schema.Required {
+			required[name] = true
+		}
+	}
+
+	var fields schema.Fields
+	seen := map[string]bool{"id": true, "created_at": true, "updated_at": true}
+	for _, s := range schemas {
+		for _, property := range s.schema.Properties {
+			if seen[property.Name] {
+				continue
+			}
+			seen[property.Name] = true
+
+			prop := d.resolve(property.Schema).schema
+			if prop == nil || prop.ReadOnly {
+				continue
+			}
+			fieldType, ok := schema.TypeForOpenAPI(prop.Type, prop.Format)
+			if !ok {
+				warn("%s: property %q has unsupported type %q, skipped", res.basePath, property.Name, prop.Type)
+				continue
+			}
+
+			var modifiers []string
+			if required[property.Name] {
+				modifiers = append(modifiers, "required")
+			}
+			field, err := schema.NewField(property.Name, fieldType, modifiers...)
+			if err != nil {
+				warn("%s: %v, skipped", res.basePath, err)
+				continue
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// jsonSchema returns the resolved application/json schema of a body
+func (d *Document) jsonSchema(content map[string]MediaType) namedSchema {
+	media, ok := content["application/json"]
+	if !ok {
+		return namedSchema{}
+	}
+	return d.resolve(media.Schema)
+}
+
+// listItems unwraps the item schema of a list response, either a plain array
+// or an envelope object holding one array property (e.g. {"data": [...]})
+func (d *Document) listItems(s namedSchema) namedSchema {
+	if s.schema == nil {
+		return s
+	}
+	if s.schema.Type == "array" {
+		return d.resolve(s.schema.Items)
+	}
+	for _, property := range s.schema.Properties {
+		if prop := d.resolve(property.Schema).schema; prop != nil && prop.Type == "array" {
+			return d.resolve(prop.Items)
+		}
+	}
+	return namedSchema{}
+}
+
+// resolve follows component references, remembering the last component name
+func (d *Document) resolve(s *Schema) namedSchema {
+	var name string
+	for depth := 0; s != nil && s.Ref != ""; depth++ {
+		if depth > 8 {
+			return namedSchema{}
+		}
+		name = strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		s = d.Components.Schemas[name]
+	}
+	return namedSchema{name: name, schema: s}
+}
@@ -0,0 +1,210 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/schema"
+)
+
+const contract = `openapi: 3.0.3
+info:
+  title: shop
+  version: 1.0.0
+paths:
+  /api/v1/order-items:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/OrderItem'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewOrderItem'
+      responses:
+        "201":
+          description: Created
+  /api/v1/order-items/{itemId}:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/OrderItem'
+  /api/v1/order-items/{itemId}/ship:
+    post:
+      responses:
+        "204":
+          description: Shipped
+  /api/v1/auth/login:
+    post:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    NewOrderItem:
+      type: object
+      required: [quantity, sku]
+      properties:
+        quantity: {type: integer, format: int32}
+        sku: {type: string}
+        note: {type: string}
+    OrderItem:
+      type: object
+      properties:
+        id: {type: string, format: uuid, readOnly: true}
+        quantity: {type: integer, format: int32}
+        sku: {type: string}
+        note: {type: string}
+        unitPrice: {type: number, format: decimal}
+        shippedAt: {type: string, format: date-time}
+        tags: {type: array, items: {type: string}}
+        createdAt: {type: string, format: date-time, readOnly: true}
+`
+
+func writeContract(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDocument_Entities(t *testing.T) {
+	doc, err := Load(writeContract(t, contract))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	entities, warnings := doc.Entities()
+	if len(entities) != 1 {
+		t.Fatalf("Entities() returned %d entities, want 1", len(entities))
+	}
+
+	entity := entities[0]
+	if entity.Name != "order-item" || entity.BasePath != "/api/v1/order-items" {
+		t.Errorf("entity = %s at %s, want order-item at /api/v1/order-items", entity.Name, entity.BasePath)
+	}
+
+	var got []string
+	for _, field := range entity.Fields {
+		spec := field.Name + ":" + field.Type
+		if field.Required {
+			spec += ":required"
+		}
+		got = append(got, spec)
+	}
+	expected := "quantity:int:required,sku:string:required,note:string,unit_price:decimal,shipped_at:time"
+	if strings.Join(got, ",") != expected {
+		t.Errorf("fields = %s, want %s", strings.Join(got, ","), expected)
+	}
+
+	for _, want := range []string{"/ship: nested and action routes", "/auth/login: auth routes", `property "tags" has unsupported type "array"`} {
+		found := false
+		for _, warning := range warnings {
+			if strings.Contains(warning, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing warning %q in %v", want, warnings)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "swagger 2", content: "swagger: \"2.0\"\npaths: {}\n", wantErr: "unsupported OpenAPI version"},
+		{name: "invalid yaml", content: "openapi: [3\n", wantErr: "api.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeContract(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Run("contract", func(t *testing.T) {
+		config := &cli.Config{
+			Entities: []string{"order-item"},
+			Fields:   map[string]schema.Fields{"orderItem": {{Name: "sku", Type: "string"}}},
+		}
+
+		if _, err := Apply(config, writeContract(t, contract)); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if len(config.Entities) != 1 {
+			t.Errorf("Entities = %v, want the declared entity only", config.Entities)
+		}
+		if len(config.Fields["orderItem"]) != 1 {
+			t.Errorf("fields given on the command line were replaced: %v", config.Fields["orderItem"])
+		}
+		if got := RoutePath(config, "orderItem"); got != "/api/v1/order-items" {
+			t.Errorf("RoutePath() = %s, want /api/v1/order-items", got)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		source := &cli.Config{
+			ModuleName: "example.com/shop",
+			Entities:   []string{"product"},
+			Fields: map[string]schema.Fields{"product": {
+				{Name: "name", Type: "string", Required: true},
+				{Name: "price", Type: "decimal"},
+				{Name: "released_at", Type: "time"},
+			}},
+			UseGin:  true,
+			UseAuth: true,
+		}
+		content, err := Build(source).YAML()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config := &cli.Config{UseGin: true}
+		if _, err := Apply(config, writeContract(t, string(content))); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if strings.Join(config.Entities, ",") != "product" {
+			t.Fatalf("Entities = %v, want [product]", config.Entities)
+		}
+		if len(config.Fields["product"]) != 3 {
+			t.Fatalf("fields = %v, want 3 fields", config.Fields["product"])
+		}
+		for i, field := range config.Fields["product"] {
+			if field != source.Fields["product"][i] {
+				t.Errorf("field %d = %+v, want %+v", i, field, source.Fields["product"][i])
+			}
+		}
+		if got := RoutePath(config, "product"); got != EntityPath("product", true) {
+			t.Errorf("RoutePath() = %s, want %s", got, EntityPath("product", true))
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+)
+
+// Bounded contexts generated for the auth feature rather than declared as entities
+var authContexts = map[string]bool{
+	"auth":       true,
+	"user":       true,
+	"role":       true,
+	"permission": true,
+}
+
+// Router modules identifying the framework of a project; chi is assumed when none is found
+var frameworkModules = []struct {
+	name   string
+	module string
+}{
+	{name: cli.FrameworkGin, module: "github.com/gin-gonic/gin"},
+	{name: cli.FrameworkEcho, module: "github.com/labstack/echo"},
+	{name: cli.FrameworkFiber, module: "github.com/gofiber/fiber"},
+}
+
+// Driver modules identifying the database of a project; postgres on database/sql is assumed when none is found
+var databaseModules = []struct {
+	name   string
+	pgx    bool
+	module string
+}{
+	{name: cli.DBMySQL, module: "gorm.io/driver/mysql"},
+	{name: cli.DBPostgres, module: "gorm.io/driver/postgres"},
+	{name: cli.DBMySQL, module: "github.com/go-sql-driver/mysql"},
+	{name: cli.DBSQLite, module: "modernc.org/sqlite"},
+	{name: cli.DBMongo, module: "go.mongodb.org/mongo-driver"},
+	{name: cli.DBPostgres, pgx: true, module: "github.com/jackc/pgx/v5/pgxpool"},
+}
+
+// Files whose presence shows an optional component was generated; a glob matching any of them is enough
+var componentFiles = []struct {
+	name     string
+	patterns []string
+}{
+	{name: cli.ComponentDocker, patterns: []string{"Dockerfile"}},
+	{name: cli.ComponentTaskfile, patterns: []string{"Taskfile.yaml"}},
+	{name: cli.ComponentMigrate, patterns: []string{"migrations/migrations.go", "pkg/migrate/migrate.go"}},
+	{name: cli.ComponentSwagger, patterns: []string{"docs/docs.go"}},
+	{name: cli.ComponentTests, patterns: []string{"internal/domain/repository/mocks", "internal/*/domain/repository/mocks"}},
+}
+
+// Detect inspects an existing gogen project and reconstructs its configuration
+func Detect(root string) (*cli.Config, error) {
+	goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("not a Go module (missing go.mod in %s): %w", root, err)
+	}
+
+	moduleName := parseModuleName(goMod)
+	if moduleName == "" {
+		return nil, fmt.Errorf("no module directive found in %s", filepath.Join(root, "go.mod"))
+	}
+
+	config := &cli.Config{ModuleName: moduleName}
+
+	switch {
+	case isDir(filepath.Join(root, "internal", "shared")):
+		config.Monolith = true
+	case isDir(filepath.Join(root, "internal", "domain")):
+		config.Monolith = false
+	default:
+		return nil, fmt.Errorf("%s does not look like a gogen project (no internal/shared or internal/domain)", root)
+	}
+
+	mainGo, _ := os.ReadFile(filepath.Join(root, "cmd", "main.go"))
+	config.Framework = detectFramework(goMod, mainGo)
+	config.UseGin = config.Framework == cli.FrameworkGin
+	dbGo, _ := os.ReadFile(filepath.Join(root, "pkg", "db", "db.go"))
+	config.DB, config.UsePgx = detectDatabase(goMod, dbGo)
+	if bytes.Contains(dbGo, []byte("gorm.io/gorm")) {
+		config.ORM = cli.ORMGorm
+	}
+	_, err = os.Stat(filepath.Join(root, "sqlc.yaml"))
+	config.UseSQLC = err == nil
+	config.UseAuth = isDir(filepath.Join(root, "internal", "auth"))
+	config.UseIntegration = isDir(filepath.Join(root, "test", "integration"))
+	config.UseGRPC = isDir(filepath.Join(root, "api", "proto")) || isDir(filepath.Join(root, "pkg", "grpcserver"))
+	config.Components = detectComponents(root)
+	if _, err := os.Stat(filepath.Join(root, "pkg", "query", "cursor.go")); err == nil {
+		config.Pagination = cli.PaginationCursor
+	}
+
+	if config.Monolith {
+		config.Entities, err = boundedContexts(root, config.UseAuth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// detectFramework returns the framework whose router cmd/main.go builds or imports, falling
+// back to the go.mod requirements; empty means chi
+func detectFramework(goMod, mainGo []byte) string {
+	if bytes.Contains(mainGo, []byte("http.NewServeMux()")) {
+		return cli.FrameworkStdlib
+	}
+	for _, source := range [][]byte{mainGo, goMod} {
+		for _, framework := range frameworkModules {
+			if bytes.Contains(source, []byte(framework.module)) {
+				return framework.name
+			}
+		}
+	}
+	return ""
+}
+
+// detectDatabase returns the database whose driver pkg/db/db.go imports, falling back to the
+// go.mod requirements; empty means postgres on database/sql
+func detectDatabase(goMod, dbGo []byte) (string, bool) {
+	for _, source := range [][]byte{dbGo, goMod} {
+		for _, database := range databaseModules {
+			if bytes.Contains(source, []byte(database.module)) {
+				return database.name, database.pgx
+			}
+		}
+	}
+	return "", false
+}
+
+// detectComponents lists the optional components whose files exist under root
+func detectComponents(root string) []string {
+	components := []string{}
+	for _, component := range componentFiles {
+		for _, pattern := range component.patterns {
+			if matches, _ := filepath.Glob(filepath.Join(root, pattern)); len(matches) > 0 {
+				components = append(components, component.name)
+				break
+			}
+		}
+	}
+	return components
+}
+
+// parseModuleName returns the module path declared in go.mod content
+func parseModuleName(goMod []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// boundedContexts lists the entity bounded contexts under internal/
+func boundedContexts(root string, useAuth bool) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, "internal"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read internal directory: %w", err)
+	}
+
+	var entities []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == "shared" || (useAuth && authContexts[name]) {
+			continue
+		}
+		if isDir(filepath.Join(root, "internal", name, "domain")) {
+			entities = append(entities, name)
+		}
+	}
+	sort.Strings(entities)
+
+	return entities, nil
+}
+
+// isDir reports whether path exists and is a directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,292 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name                string
+		goMod               string
+		dirs                []string
+		files               []string
+		mainGo              string
+		dbGo                string
+		expectedMonolith    bool
+		expectedGin         bool
+		expectedFramework   string
+		expectedAuth        bool
+		expectedSQLC        bool
+		expectedORM         string
+		expectedIntegration bool
+		expectedPagination  string
+		expectedGRPC        bool
+		expectedComponents  []string
+		expectedEntities    []string
+	}{
+		{
+			name:              "microservice with chi",
+			goMod:             "module github.com/test/svc\n\ngo 1.24\n",
+			dirs:              []string{"internal/domain/entity", "cmd"},
+			expectedFramework: "chi",
+		},
+		{
+			name:              "monolith with gin",
+			goMod:             "module github.com/test/shop\n\ngo 1.24\n\nrequire github.com/gin-gonic/gin v1.10.0\n",
+			dirs:              []string{"internal/shared/dto", "internal/product/domain", "internal/order/domain", "cmd"},
+			expectedMonolith:  true,
+			expectedGin:       true,
+			expectedFramework: "gin",
+			expectedEntities:  []string{"order", "product"},
+		},
+		{
+			name:              "microservice with echo",
+			goMod:             "module github.com/test/svc\n\ngo 1.24\n\nrequire github.com/labstack/echo/v4 v4.13.3\n",
+			dirs:              []string{"internal/domain/entity", "cmd"},
+			expectedFramework: "echo",
+		},
+		{
+			name:              "main.go import wins over go.mod",
+			goMod:             "module github.com/test/svc\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.10.0\n\tgithub.com/gofiber/fiber/v2 v2.52.5\n)\n",
+			dirs:              []string{"internal/domain/entity", "cmd"},
+			mainGo:            "package main\n\nimport \"github.com/gofiber/fiber/v2\"\n",
+			expectedFramework: "fiber",
+		},
+		{
+			name:              "microservice with stdlib",
+			goMod:             "module github.com/test/svc\n\ngo 1.24\n",
+			dirs:              []string{"internal/domain/entity", "cmd"},
+			mainGo:            "package main\n\nfunc run() {\n\trouter := http.NewServeMux()\n}\n",
+			expectedFramework: "stdlib",
+		},
+		{
+			name:              "monolith with auth contexts",
+			goMod:             "module github.com/test/shop\n",
+			dirs:              []string{"internal/shared", "internal/auth/application", "internal/user/domain", "internal/product/domain"},
+			mainGo:            "package main\n\nimport \"github.com/go-chi/chi/v5\"\n",
+			expectedMonolith:  true,
+			expectedAuth:      true,
+			expectedFramework: "chi",
+			expectedEntities:  []string{"product"},
+		},
+		{
+			name:              "monolith with sqlc",
+			goMod:             "module github.com/test/shop\n",
+			dirs:              []string{"internal/shared/sqlcdb", "internal/product/domain"},
+			expectedMonolith:  true,
+			expectedSQLC:      true,
+			expectedFramework: "chi",
+			expectedEntities:  []string{"product"},
+		},
+		{
+			name:              "microservice with gorm",
+			goMod:             "module github.com/test/svc\n\nrequire gorm.io/gorm v1.31.0\n",
+			dirs:              []string{"internal/domain/entity", "cmd", "pkg/db"},
+			dbGo:              "package db\n\nimport (\n\t\"gorm.io/driver/mysql\"\n\t\"gorm.io/gorm\"\n)\n",
+			expectedFramework: "chi",
+			expectedORM:       "gorm",
+		},
+		{
+			name:                "microservice with integration tests",
+			goMod:               "module github.com/test/svc\n",
+			dirs:                []string{"internal/domain/entity", "test/integration"},
+			expectedFramework:   "chi",
+			expectedIntegration: true,
+		},
+		{
+			name:               "microservice with cursor pagination",
+			goMod:              "module github.com/test/svc\n",
+			dirs:               []string{"internal/domain/entity", "pkg/query"},
+			expectedFramework:  "chi",
+			expectedPagination: "cursor",
+		},
+		{
+			name:               "microservice with grpc",
+			goMod:              "module github.com/test/svc\n",
+			dirs:               []string{"internal/domain/entity", "api/proto/product/v1", "pkg/grpcserver"},
+			expectedFramework:  "chi",
+			expectedGRPC:       true,
+			expectedComponents: []string{},
+		},
+		{
+			name:               "microservice with every component",
+			goMod:              "module github.com/test/svc\n",
+			dirs:               []string{"internal/domain/entity", "internal/domain/repository/mocks"},
+			files:              []string{"Dockerfile", "Taskfile.yaml", "migrations/migrations.go", "pkg/migrate/migrate.go", "docs/docs.go"},
+			expectedFramework:  "chi",
+			expectedComponents: []string{"docker", "taskfile", "migrate", "swagger", "tests"},
+		},
+		{
+			name:               "monolith with some components",
+			goMod:              "module github.com/test/shop\n",
+			dirs:               []string{"internal/shared", "internal/product/domain/repository/mocks"},
+			files:              []string{"Dockerfile", "migrations/migrations.go"},
+			expectedMonolith:   true,
+			expectedFramework:  "chi",
+			expectedComponents: []string{"docker", "migrate", "tests"},
+			expectedEntities:   []string{"product"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFile(t, filepath.Join(root, "go.mod"), tt.goMod)
+			for _, dir := range tt.dirs {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+					t.Fatalf("Failed to create directory %s: %v", dir, err)
+				}
+			}
+			for _, file := range tt.files {
+				writeFile(t, filepath.Join(root, file), "")
+			}
+			if tt.mainGo != "" {
+				writeFile(t, filepath.Join(root, "cmd", "main.go"), tt.mainGo)
+			}
+			if tt.expectedSQLC {
+				writeFile(t, filepath.Join(root, "sqlc.yaml"), "version: \"2\"\n")
+			}
+			if tt.dbGo != "" {
+				writeFile(t, filepath.Join(root, "pkg", "db", "db.go"), tt.dbGo)
+			}
+			if tt.expectedPagination == "cursor" {
+				writeFile(t, filepath.Join(root, "pkg", "query", "cursor.go"), "package query\n")
+			}
+
+			config, err := Detect(root)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			if config.Monolith != tt.expectedMonolith {
+				t.Errorf("Monolith = %v, want %v", config.Monolith, tt.expectedMonolith)
+			}
+			if config.UseGin != tt.expectedGin {
+				t.Errorf("UseGin = %v, want %v", config.UseGin, tt.expectedGin)
+			}
+			if framework := config.HTTPFramework(); framework != tt.expectedFramework {
+				t.Errorf("HTTPFramework() = %v, want %v", framework, tt.expectedFramework)
+			}
+			if config.UseAuth != tt.expectedAuth {
+				t.Errorf("UseAuth = %v, want %v", config.UseAuth, tt.expectedAuth)
+			}
+			if config.UseSQLC != tt.expectedSQLC {
+				t.Errorf("UseSQLC = %v, want %v", config.UseSQLC, tt.expectedSQLC)
+			}
+			if config.ORM != tt.expectedORM {
+				t.Errorf("ORM = %q, want %q", config.ORM, tt.expectedORM)
+			}
+			if config.UseIntegration != tt.expectedIntegration {
+				t.Errorf("UseIntegration = %v, want %v", config.UseIntegration, tt.expectedIntegration)
+			}
+			if config.Pagination != tt.expectedPagination {
+				t.Errorf("Pagination = %q, want %q", config.Pagination, tt.expectedPagination)
+			}
+			if config.UseGRPC != tt.expectedGRPC {
+				t.Errorf("UseGRPC = %v, want %v", config.UseGRPC, tt.expectedGRPC)
+			}
+			if tt.expectedComponents != nil && !reflect.DeepEqual(config.Components, tt.expectedComponents) {
+				t.Errorf("Components = %v, want %v", config.Components, tt.expectedComponents)
+			}
+			if !reflect.DeepEqual(config.Entities, tt.expectedEntities) {
+				t.Errorf("Entities = %v, want %v", config.Entities, tt.expectedEntities)
+			}
+		})
+	}
+}
+
+func TestDetect_NotAProject(t *testing.T) {
+	root := t.TempDir()
+
+	if _, err := Detect(root); err == nil {
+		t.Error("Detect() expected error for directory without go.mod, got nil")
+	}
+
+	writeFile(t, filepath.Join(root, "go.mod"), "module github.com/test/other\n")
+	if _, err := Detect(root); err == nil {
+		t.Error("Detect() expected error for non-gogen layout, got nil")
+	}
+}
+
+func TestDetectDatabase(t *testing.T) {
+	tests := []struct {
+		name        string
+		goMod       string
+		dbGo        string
+		expectedDB  string
+		expectedPgx bool
+	}{
+		{
+			name:  "postgres on database/sql",
+			goMod: "module github.com/test/svc\n\nrequire github.com/lib/pq v1.10.9\n",
+		},
+		{
+			name:       "mysql driver in go.mod",
+			goMod:      "module github.com/test/svc\n\nrequire github.com/go-sql-driver/mysql v1.8.1\n",
+			expectedDB: "mysql",
+		},
+		{
+			name:       "sqlite driver imported by pkg/db",
+			goMod:      "module github.com/test/svc\n",
+			dbGo:       "package db\n\nimport _ \"modernc.org/sqlite\"\n",
+			expectedDB: "sqlite",
+		},
+		{
+			name:       "mongo driver in go.mod",
+			goMod:      "module github.com/test/svc\n\nrequire go.mongodb.org/mongo-driver/v2 v2.2.0\n",
+			expectedDB: "mongo",
+		},
+		{
+			name:       "gorm postgres dialector",
+			goMod:      "module github.com/test/svc\n\nrequire github.com/jackc/pgx/v5 v5.7.5 // indirect\n",
+			dbGo:       "package db\n\nimport (\n\t\"gorm.io/driver/postgres\"\n\t\"gorm.io/gorm\"\n)\n",
+			expectedDB: "postgres",
+		},
+		{
+			name:        "pgx pool",
+			goMod:       "module github.com/test/svc\n",
+			dbGo:        "package db\n\nimport \"github.com/jackc/pgx/v5/pgxpool\"\n",
+			expectedDB:  "postgres",
+			expectedPgx: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, pgx := detectDatabase([]byte(tt.goMod), []byte(tt.dbGo))
+			if db != tt.expectedDB || pgx != tt.expectedPgx {
+				t.Errorf("detectDatabase() = (%q, %v), want (%q, %v)", db, pgx, tt.expectedDB, tt.expectedPgx)
+			}
+		})
+	}
+}
+
+func TestParseModuleName(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"module github.com/test/project\n\ngo 1.24\n", "github.com/test/project"},
+		{"// comment\nmodule \"github.com/test/quoted\"\n", "github.com/test/quoted"},
+		{"go 1.24\n", ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseModuleName([]byte(tt.content)); got != tt.expected {
+			t.Errorf("parseModuleName(%q) = %v, want %v", tt.content, got, tt.expected)
+		}
+	}
+}
+
+// writeFile writes content to path, creating parent directories
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
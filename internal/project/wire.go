@@ -0,0 +1,83 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Markers in generated cmd/main.go where new bounded contexts are wired in
+const (
+	ImportsMarker = "// gogen:imports"
+	RoutesMarker  = "// gogen:routes"
+)
+
+// ErrNoMarkers is returned when main.go lacks the gogen insertion markers
+var ErrNoMarkers = errors.New("insertion markers not found")
+
+// WireMain inserts import and wiring snippets above the gogen markers in main.go.
+// It reports false without touching the file when guard is already present.
+func WireMain(path string, imports, wiring []byte, guard string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if bytes.Contains(content, []byte(guard)) {
+		return false, nil
+	}
+
+	if err := checkMarkers(path, content); err != nil {
+		return false, err
+	}
+
+	content = insertBeforeMarker(content, ImportsMarker, imports)
+	content = insertBeforeMarker(content, RoutesMarker, wiring)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, content, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// CheckMarkers returns ErrNoMarkers when main.go lacks either gogen insertion marker, so callers
+// can refuse to add an entity before writing any of its files
+func CheckMarkers(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return checkMarkers(path, content)
+}
+
+// checkMarkers returns ErrNoMarkers when content lacks either gogen insertion marker
+func checkMarkers(path string, content []byte) error {
+	if !bytes.Contains(content, []byte(ImportsMarker)) || !bytes.Contains(content, []byte(RoutesMarker)) {
+		return fmt.Errorf("%s: %w (expected %q and %q)", path, ErrNoMarkers, ImportsMarker, RoutesMarker)
+	}
+	return nil
+}
+
+// insertBeforeMarker inserts snippet on the lines directly above the first marker line
+func insertBeforeMarker(content []byte, marker string, snippet []byte) []byte {
+	idx := bytes.Index(content, []byte(marker))
+	lineStart := bytes.LastIndexByte(content[:idx], '\n') + 1
+
+	snippet = bytes.Trim(snippet, "\n")
+	if len(snippet) == 0 {
+		return content
+	}
+
+	result := make([]byte, 0, len(content)+len(snippet)+1)
+	result = append(result, content[:lineStart]...)
+	result = append(result, snippet...)
+	result = append(result, '\n')
+	result = append(result, content[lineStart:]...)
+	return result
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMain = `package main
+
+import (
+	"fmt"
+	// gogen:imports
+)
+
+func main() {
+	// custom user code
+	// gogen:routes
+	fmt.Println("start")
+}
+`
+
+func TestWireMain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	writeFile(t, path, testMain)
+
+	imports := []byte("\n\torder_app \"example.com/shop/internal/order/application\"\n")
+	wiring := []byte("\n\torderService := order_app.NewOrderService(nil)\n")
+	guard := `"example.com/shop/internal/order/application"`
+
+	wired, err := WireMain(path, imports, wiring, guard)
+	if err != nil {
+		t.Fatalf("WireMain() error = %v", err)
+	}
+	if !wired {
+		t.Fatal("WireMain() = false, want true")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+
+	expected := `package main
+
+import (
+	"fmt"
+	order_app "example.com/shop/internal/order/application"
+	// gogen:imports
+)
+
+func main() {
+	// custom user code
+	orderService := order_app.NewOrderService(nil)
+	// gogen:routes
+	fmt.Println("start")
+}
+`
+	if string(content) != expected {
+		t.Errorf("WireMain() content = %q, want %q", string(content), expected)
+	}
+
+	// Running again must not duplicate the wiring
+	wired, err = WireMain(path, imports, wiring, guard)
+	if err != nil {
+		t.Fatalf("WireMain() second run error = %v", err)
+	}
+	if wired {
+		t.Error("WireMain() second run = true, want false")
+	}
+}
+
+func TestWireMain_NoMarkers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	writeFile(t, path, "package main\n\nfunc main() {}\n")
+
+	_, err := WireMain(path, []byte("x"), []byte("y"), "guard")
+	if !errors.Is(err, ErrNoMarkers) {
+		t.Errorf("WireMain() error = %v, want ErrNoMarkers", err)
+	}
+}
+
+func TestCheckMarkers(t *testing.T) {
+	dir := t.TempDir()
+	withMarkers := filepath.Join(dir, "with.go")
+	writeFile(t, withMarkers, testMain)
+	withoutRoutes := filepath.Join(dir, "without.go")
+	writeFile(t, withoutRoutes, "package main\n\nimport (\n\t// gogen:imports\n)\n")
+
+	if err := CheckMarkers(withMarkers); err != nil {
+		t.Errorf("CheckMarkers() error = %v, want nil", err)
+	}
+	if err := CheckMarkers(withoutRoutes); !errors.Is(err, ErrNoMarkers) {
+		t.Errorf("CheckMarkers() error = %v, want ErrNoMarkers", err)
+	}
+	if err := CheckMarkers(filepath.Join(dir, "missing.go")); err == nil || errors.Is(err, ErrNoMarkers) {
+		t.Errorf("CheckMarkers() of a missing file error = %v, want a read error", err)
+	}
+}
@@ -0,0 +1,58 @@
+package protoc
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Tools lists the executables needed to compile Go and gRPC stubs
+var Tools = []string{"protoc", "protoc-gen-go", "protoc-gen-go-grpc"}
+
+// Compiler runs protoc with the Go and gRPC plugins
+type Compiler struct {
+	projectRoot string
+}
+
+// NewCompiler creates a new protoc compiler for the project
+func NewCompiler(projectRoot string) *Compiler {
+	return &Compiler{
+		projectRoot: projectRoot,
+	}
+}
+
+// Missing returns the tools that are not installed on PATH
+func (c *Compiler) Missing() []string {
+	var missing []string
+	for _, tool := range Tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+	return missing
+}
+
+// Generate writes the .pb.go and _grpc.pb.go stubs next to each .proto file
+func (c *Compiler) Generate(files []string) error {
+	cmd := exec.Command("protoc", Args(files)...)
+	cmd.Dir = c.projectRoot
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("protoc failed: %w", err)
+	}
+
+	return nil
+}
+
+// Args returns the protoc arguments for files relative to the project root;
+// the generated Taskfile runs the same command
+func Args(files []string) []string {
+	args := []string{
+		"-I", ".",
+		"--go_out=.", "--go_opt=paths=source_relative",
+		"--go-grpc_out=.", "--go-grpc_opt=paths=source_relative",
+	}
+	return append(args, files...)
+}
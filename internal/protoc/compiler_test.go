@@ -0,0 +1,76 @@
+package protoc
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	args := strings.Join(Args([]string{"api/proto/user/v1/user.proto"}), " ")
+	expected := "-I . --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api/proto/user/v1/user.proto"
+	if args != expected {
+		t.Errorf("Args() = %s, want %s", args, expected)
+	}
+}
+
+func TestCompiler_Missing(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	tests := []struct {
+		name      string
+		installed []string
+		expected  []string
+	}{
+		{name: "nothing installed", expected: Tools},
+		{name: "plugins missing", installed: []string{"protoc"}, expected: []string{"protoc-gen-go", "protoc-gen-go-grpc"}},
+		{name: "all installed", installed: Tools, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bin := t.TempDir()
+			for _, tool := range tt.installed {
+				if err := os.WriteFile(filepath.Join(bin, tool), []byte("#!/bin/sh\n"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("PATH", bin)
+
+			missing := NewCompiler("project").Missing()
+			if strings.Join(missing, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Missing() = %v, want %v", missing, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompiler_Generate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" > args.txt\n"
+	if err := os.WriteFile(filepath.Join(bin, "protoc"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	projectRoot := t.TempDir()
+	if err := NewCompiler(projectRoot).Generate([]string{"api/proto/user/v1/user.proto"}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectRoot, "args.txt"))
+	if err != nil {
+		t.Fatalf("protoc did not run in the project root: %v", err)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(string(content)), "api/proto/user/v1/user.proto") {
+		t.Errorf("protoc args = %s", content)
+	}
+}
@@ -0,0 +1,203 @@
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Conflict policies applied when a generated file already exists on disk
+const (
+	ConflictFail      = "fail"
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictPrompt    = "prompt"
+	ConflictDiff      = "diff"
+)
+
+// ConflictPolicies lists every supported conflict policy
+var ConflictPolicies = []string{ConflictFail, ConflictSkip, ConflictOverwrite, ConflictPrompt, ConflictDiff}
+
+// ErrConflict is returned under the fail policy when a file already exists
+var ErrConflict = errors.New("file already exists")
+
+// WriteSummary records what happened to each file during a generation run
+type WriteSummary struct {
+	Created     []string
+	Overwritten []string
+	Skipped     []string
+	Unchanged   []string
+}
+
+// FileWriter writes generated files while applying a conflict policy to existing ones
+type FileWriter struct {
+	policy  string
+	in      *bufio.Reader
+	out     io.Writer
+	written map[string]bool
+	summary WriteSummary
+	// staged holds the content of the files written since Batch, by path, and order the paths in
+	// the order they were first written; staged is nil outside a batch
+	staged map[string][]byte
+	order  []string
+}
+
+// NewFileWriter creates a writer; in and out are used by the prompt and diff policies
+func NewFileWriter(policy string, in io.Reader, out io.Writer) *FileWriter {
+	if policy == "" {
+		policy = ConflictFail
+	}
+
+	return &FileWriter{
+		policy:  policy,
+		in:      bufio.NewReader(in),
+		out:     out,
+		written: make(map[string]bool),
+	}
+}
+
+// Write writes content to path, consulting the conflict policy if the file existed before this run.
+// During a batch the content is only staged for Flush
+func (w *FileWriter) Write(path string, content []byte) error {
+	if w.staged != nil {
+		if _, ok := w.staged[path]; !ok {
+			w.order = append(w.order, path)
+		}
+		w.staged[path] = content
+		return nil
+	}
+
+	// Files rendered earlier in the same run (e.g. shared files per entity) follow the first
+	// decision: ours are simply replaced, skipped ones are left alone without asking again
+	if owned, decided := w.written[path]; decided {
+		if !owned {
+			return nil
+		}
+		return w.writeFile(path, content)
+	}
+
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		w.summary.Created = append(w.summary.Created, path)
+		return w.writeFile(path, content)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read existing file %s: %w", path, err)
+	}
+
+	if bytes.Equal(existing, content) {
+		w.written[path] = true
+		w.summary.Unchanged = append(w.summary.Unchanged, path)
+		return nil
+	}
+
+	overwrite, err := w.resolve(path, existing, content)
+	if err != nil {
+		return err
+	}
+	if !overwrite {
+		w.written[path] = false
+		w.summary.Skipped = append(w.summary.Skipped, path)
+		return nil
+	}
+
+	w.summary.Overwritten = append(w.summary.Overwritten, path)
+	return w.writeFile(path, content)
+}
+
+// Batch stages the files written from now on until Flush, so each path is weighed against the
+// existing file once, with the content it ends up with; shared files rendered once per entity
+// then match an unchanged file on disk
+func (w *FileWriter) Batch() {
+	w.staged = make(map[string][]byte)
+	w.order = nil
+}
+
+// Flush writes the files staged since Batch and ends the batch. Under the fail policy every
+// conflicting file is reported at once, before any file is written
+func (w *FileWriter) Flush() error {
+	staged, order := w.staged, w.order
+	w.staged, w.order = nil, nil
+
+	if w.policy == ConflictFail {
+		var conflicts []string
+		for _, path := range order {
+			existing, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) || w.written[path] {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read existing file %s: %w", path, err)
+			}
+			if !bytes.Equal(existing, staged[path]) {
+				conflicts = append(conflicts, path)
+			}
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("%w, no file was written (use --on-conflict=skip|overwrite|prompt|diff):\n  %s",
+				ErrConflict, strings.Join(conflicts, "\n  "))
+		}
+	}
+
+	for _, path := range order {
+		if err := w.Write(path, staged[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Summary returns what happened to the files written so far
+func (w *FileWriter) Summary() WriteSummary {
+	return w.summary
+}
+
+// resolve decides whether an existing, different file should be overwritten
+func (w *FileWriter) resolve(path string, existing, content []byte) (bool, error) {
+	switch w.policy {
+	case ConflictSkip:
+		return false, nil
+	case ConflictOverwrite:
+		return true, nil
+	case ConflictDiff:
+		fmt.Fprint(w.out, UnifiedDiff(path, existing, content))
+		return false, nil
+	case ConflictPrompt:
+		return w.prompt(path, existing, content)
+	default:
+		return false, fmt.Errorf("%s: %w (use --on-conflict=skip|overwrite|prompt|diff)", path, ErrConflict)
+	}
+}
+
+// prompt asks the user what to do with a conflicting file
+func (w *FileWriter) prompt(path string, existing, content []byte) (bool, error) {
+	for {
+		fmt.Fprintf(w.out, "%s already exists. Overwrite? [y]es/[n]o/[d]iff: ", path)
+		answer, err := w.in.ReadString('\n')
+		if err != nil && answer == "" {
+			return false, fmt.Errorf("failed to read answer for %s: %w", path, err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no", "":
+			return false, nil
+		case "d", "diff":
+			fmt.Fprint(w.out, UnifiedDiff(path, existing, content))
+		}
+	}
+}
+
+// writeFile writes content and remembers the path as produced by this run
+func (w *FileWriter) writeFile(path string, content []byte) error {
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	w.written[path] = true
+	return nil
+}
@@ -0,0 +1,325 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goembed "github.com/indalyadav56/gogen"
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileWriter_Write(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		input       string
+		wantErr     error
+		wantContent string
+		wantSummary WriteSummary
+	}{
+		{
+			name:        "fail refuses existing file",
+			policy:      ConflictFail,
+			wantErr:     ErrConflict,
+			wantContent: "old\n",
+		},
+		{
+			name:        "default policy is fail",
+			policy:      "",
+			wantErr:     ErrConflict,
+			wantContent: "old\n",
+		},
+		{
+			name:        "skip keeps existing file",
+			policy:      ConflictSkip,
+			wantContent: "old\n",
+			wantSummary: WriteSummary{Skipped: []string{"existing.go"}},
+		},
+		{
+			name:        "overwrite replaces existing file",
+			policy:      ConflictOverwrite,
+			wantContent: "new\n",
+			wantSummary: WriteSummary{Overwritten: []string{"existing.go"}},
+		},
+		{
+			name:        "diff keeps existing file",
+			policy:      ConflictDiff,
+			wantContent: "old\n",
+			wantSummary: WriteSummary{Skipped: []string{"existing.go"}},
+		},
+		{
+			name:        "prompt accepted",
+			policy:      ConflictPrompt,
+			input:       "y\n",
+			wantContent: "new\n",
+			wantSummary: WriteSummary{Overwritten: []string{"existing.go"}},
+		},
+		{
+			name:        "prompt shows diff then declines",
+			policy:      ConflictPrompt,
+			input:       "d\nn\n",
+			wantContent: "old\n",
+			wantSummary: WriteSummary{Skipped: []string{"existing.go"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "existing.go")
+			if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			writer := NewFileWriter(tt.policy, strings.NewReader(tt.input), &out)
+			err := writer.Write(path, []byte("new\n"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Write() error = %v, want %v", err, tt.wantErr)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.wantContent {
+				t.Errorf("file content = %q, want %q", content, tt.wantContent)
+			}
+
+			summary := relativeSummary(writer.Summary(), dir)
+			if !equalStrings(summary.Overwritten, tt.wantSummary.Overwritten) || !equalStrings(summary.Skipped, tt.wantSummary.Skipped) {
+				t.Errorf("Summary() = %+v, want %+v", summary, tt.wantSummary)
+			}
+
+			if tt.policy == ConflictDiff && !strings.Contains(out.String(), "-old\n+new\n") {
+				t.Errorf("expected diff output, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestFileWriter_WriteNewAndUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	created := filepath.Join(dir, "created.go")
+	unchanged := filepath.Join(dir, "unchanged.go")
+	if err := os.WriteFile(unchanged, []byte("same\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := NewFileWriter(ConflictFail, strings.NewReader(""), &bytes.Buffer{})
+	if err := writer.Write(created, []byte("first\n")); err != nil {
+		t.Fatalf("Write() new file error = %v", err)
+	}
+	// Files produced earlier in the same run are replaced without consulting the policy
+	if err := writer.Write(created, []byte("second\n")); err != nil {
+		t.Fatalf("Write() same-run file error = %v", err)
+	}
+	if err := writer.Write(unchanged, []byte("same\n")); err != nil {
+		t.Fatalf("Write() identical file error = %v", err)
+	}
+
+	summary := relativeSummary(writer.Summary(), dir)
+	if !equalStrings(summary.Created, []string{"created.go"}) {
+		t.Errorf("Created = %v, want [created.go]", summary.Created)
+	}
+	if !equalStrings(summary.Unchanged, []string{"unchanged.go"}) {
+		t.Errorf("Unchanged = %v, want [unchanged.go]", summary.Unchanged)
+	}
+
+	content, _ := os.ReadFile(created)
+	if string(content) != "second\n" {
+		t.Errorf("created.go = %q, want %q", content, "second\n")
+	}
+}
+
+func TestFileWriter_WriteSamePathTwice(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		input       string
+		wantContent string
+		wantSummary WriteSummary
+	}{
+		{
+			name:        "skip",
+			policy:      ConflictSkip,
+			wantContent: "old\n",
+			wantSummary: WriteSummary{Skipped: []string{"shared.go"}},
+		},
+		{
+			name:        "diff",
+			policy:      ConflictDiff,
+			wantContent: "old\n",
+			wantSummary: WriteSummary{Skipped: []string{"shared.go"}},
+		},
+		{
+			name:        "prompt declined once",
+			policy:      ConflictPrompt,
+			input:       "n\n",
+			wantContent: "old\n",
+			wantSummary: WriteSummary{Skipped: []string{"shared.go"}},
+		},
+		{
+			name:        "prompt accepted once",
+			policy:      ConflictPrompt,
+			input:       "y\n",
+			wantContent: "second\n",
+			wantSummary: WriteSummary{Overwritten: []string{"shared.go"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "shared.go")
+			if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			writer := NewFileWriter(tt.policy, strings.NewReader(tt.input), &out)
+			// Shared files such as cmd/main.go are rendered once per entity; the policy is consulted once
+			for _, content := range []string{"first\n", "second\n"} {
+				if err := writer.Write(path, []byte(content)); err != nil {
+					t.Fatalf("Write(%q) error = %v", content, err)
+				}
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.wantContent {
+				t.Errorf("file content = %q, want %q", content, tt.wantContent)
+			}
+
+			summary := relativeSummary(writer.Summary(), dir)
+			if !equalStrings(summary.Overwritten, tt.wantSummary.Overwritten) || !equalStrings(summary.Skipped, tt.wantSummary.Skipped) {
+				t.Errorf("Summary() = %+v, want %+v", summary, tt.wantSummary)
+			}
+
+			if n := strings.Count(out.String(), "Overwrite?"); tt.policy == ConflictPrompt && n != 1 {
+				t.Errorf("prompted %d times, want once: %q", n, out.String())
+			}
+			if n := strings.Count(out.String(), "+first\n"); tt.policy == ConflictDiff && n != 1 {
+				t.Errorf("diff printed %d times, want once: %q", n, out.String())
+			}
+		})
+	}
+}
+
+func TestFileWriter_Batch(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a.go": "old a\n", "b.go": "old b\n", "shared.go": "second\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stage := func(writer *FileWriter) {
+		writer.Batch()
+		for _, file := range []struct{ name, content string }{
+			{"created.go", "new\n"},
+			{"a.go", "new a\n"},
+			// a shared file rendered once per entity ends up unchanged
+			{"shared.go", "first\n"},
+			{"shared.go", "second\n"},
+			{"b.go", "new b\n"},
+		} {
+			if err := writer.Write(filepath.Join(dir, file.name), []byte(file.content)); err != nil {
+				t.Fatalf("Write(%s) during a batch error = %v", file.name, err)
+			}
+		}
+	}
+
+	writer := NewFileWriter(ConflictFail, strings.NewReader(""), &bytes.Buffer{})
+	stage(writer)
+	err := writer.Flush()
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Flush() error = %v, want ErrConflict", err)
+	}
+	// every conflict is reported, and none of the staged files is written
+	for _, name := range []string{"a.go", "b.go"} {
+		if !strings.Contains(err.Error(), filepath.Join(dir, name)) {
+			t.Errorf("Flush() error %q does not name %s", err, name)
+		}
+	}
+	if strings.Contains(err.Error(), "shared.go") {
+		t.Errorf("Flush() error %q names the unchanged shared.go", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "created.go")); !os.IsNotExist(err) {
+		t.Errorf("created.go was written despite the conflicts (stat error = %v)", err)
+	}
+
+	writer = NewFileWriter(ConflictOverwrite, strings.NewReader(""), &bytes.Buffer{})
+	stage(writer)
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	summary := relativeSummary(writer.Summary(), dir)
+	want := WriteSummary{Created: []string{"created.go"}, Overwritten: []string{"a.go", "b.go"}, Unchanged: []string{"shared.go"}}
+	if !equalStrings(summary.Created, want.Created) || !equalStrings(summary.Overwritten, want.Overwritten) ||
+		!equalStrings(summary.Unchanged, want.Unchanged) || len(summary.Skipped) != 0 {
+		t.Errorf("Summary() = %+v, want %+v", summary, want)
+	}
+}
+
+func TestFileGenerator_RegenerateUnchanged(t *testing.T) {
+	root := t.TempDir()
+	config := &cli.Config{ModuleName: "example.com/shop", Monolith: true, Entities: []string{"product", "order"}}
+	generate := func() (*FileGenerator, error) {
+		fg := NewFileGenerator(template.NewRenderer(goembed.TemplateFS), root, config)
+		fg.Batch()
+		for _, entityName := range config.Entities {
+			if err := fg.GenerateFiles(entityName); err != nil {
+				return nil, err
+			}
+		}
+		return fg, fg.Flush()
+	}
+
+	if _, err := generate(); err != nil {
+		t.Fatalf("first generation error = %v", err)
+	}
+	// cmd/main.go is rendered once per entity; a rerun under the fail policy finds every file unchanged
+	fg, err := generate()
+	if err != nil {
+		t.Fatalf("second generation error = %v", err)
+	}
+	if summary := fg.Summary(); len(summary.Created)+len(summary.Overwritten)+len(summary.Skipped) != 0 {
+		t.Errorf("second generation changed files: %+v", summary)
+	}
+}
+
+func relativeSummary(summary WriteSummary, dir string) WriteSummary {
+	rel := func(paths []string) []string {
+		var out []string
+		for _, path := range paths {
+			r, _ := filepath.Rel(dir, path)
+			out = append(out, r)
+		}
+		return out
+	}
+	return WriteSummary{
+		Created:     rel(summary.Created),
+		Overwritten: rel(summary.Overwritten),
+		Skipped:     rel(summary.Skipped),
+		Unchanged:   rel(summary.Unchanged),
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package scaffold
+
+import "github.com/indalyadav56/gogen/internal/cli"
+
+// Database is the template set that renders the persistence layer for one database
+type Database struct {
+	Name string
+	// Package is the infrastructure package holding the repository implementation
+	Package string
+	// RepositoryTemplate renders an entity's repository implementation
+	RepositoryTemplate string
+	// DBTemplate renders pkg/db/db.go, which opens the connection pool
+	DBTemplate string
+}
+
+// databases holds the template set of every database in cli.KnownDatabases
+var databases = map[string]Database{
+	cli.DBPostgres: {
+		Name:               cli.DBPostgres,
+		Package:            "postgres",
+		RepositoryTemplate: "postgres_repository.tmpl",
+		DBTemplate:         "db.tmpl",
+	},
+	cli.DBMySQL: {
+		Name:               cli.DBMySQL,
+		Package:            "mysql",
+		RepositoryTemplate: "sql_repository.tmpl",
+		DBTemplate:         "db.tmpl",
+	},
+	cli.DBSQLite: {
+		Name:               cli.DBSQLite,
+		Package:            "sqlite",
+		RepositoryTemplate: "sql_repository.tmpl",
+		DBTemplate:         "db.tmpl",
+	},
+	cli.DBMongo: {
+		Name:               cli.DBMongo,
+		Package:            "mongo",
+		RepositoryTemplate: "mongo_repository.tmpl",
+		DBTemplate:         "mongo_db.tmpl",
+	},
+}
+
+// pgxPostgres replaces the database/sql Postgres templates when --pgx is set
+var pgxPostgres = Database{
+	Name:               cli.DBPostgres,
+	Package:            "postgres",
+	RepositoryTemplate: "pgx_repository.tmpl",
+	DBTemplate:         "pgx_db.tmpl",
+}
+
+// gormRepositoryTemplate and gormDBTemplate replace the SQL templates when --orm=gorm is set
+const (
+	gormRepositoryTemplate = "gorm_repository.tmpl"
+	gormDBTemplate         = "gorm_db.tmpl"
+)
+
+// sqlcRepositoryTemplate replaces the Postgres repository template when --sqlc is set
+const sqlcRepositoryTemplate = "sqlc_repository.tmpl"
+
+// DatabaseFor returns the template set of the configured database, falling back to Postgres
+func DatabaseFor(config *cli.Config) Database {
+	d, ok := databases[config.Database()]
+	if !ok {
+		d = databases[cli.DBPostgres]
+	}
+	if config.UsePgx {
+		d = pgxPostgres
+	}
+	if config.UseGORM() {
+		// the repositories and pool are GORM's; the infrastructure package still names the database
+		d.RepositoryTemplate, d.DBTemplate = gormRepositoryTemplate, gormDBTemplate
+	}
+	if config.UseSQLC {
+		// the repositories wrap the sqlc code, which runs on database/sql or pgx like the pool in pkg/db
+		d.RepositoryTemplate = sqlcRepositoryTemplate
+	}
+	return d
+}
+
+// database returns the template set of the configured database
+func (fg *FileGenerator) database() Database {
+	return DatabaseFor(fg.config)
+}
+
+// authRepositoryTemplate returns the repository template of an auth bounded
+// context (user, role or permission)
+func (fg *FileGenerator) authRepositoryTemplate(context string) string {
+	if fg.config.UseGORM() {
+		return "gorm_" + context + "_repository.tmpl"
+	}
+	return context + "_postgres.tmpl"
+}
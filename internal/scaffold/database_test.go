@@ -0,0 +1,120 @@
+package scaffold
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestDatabases_CoverKnownDatabases(t *testing.T) {
+	sets := []Database{
+		pgxPostgres,
+		{Name: "sqlc", RepositoryTemplate: sqlcRepositoryTemplate, DBTemplate: "db.tmpl"},
+		{Name: "gorm", RepositoryTemplate: gormRepositoryTemplate, DBTemplate: gormDBTemplate},
+	}
+	for _, name := range cli.KnownDatabases {
+		d, ok := databases[name]
+		if !ok {
+			t.Errorf("database %q has no template set", name)
+			continue
+		}
+		if d.Name != name {
+			t.Errorf("databases[%q].Name = %q", name, d.Name)
+		}
+		sets = append(sets, d)
+	}
+
+	for _, d := range sets {
+		for _, tmpl := range []string{d.RepositoryTemplate, d.DBTemplate} {
+			if _, err := os.Stat(filepath.Join("..", "..", "templates", tmpl)); err != nil {
+				t.Errorf("database %q: template %s: %v", d.Name, tmpl, err)
+			}
+		}
+	}
+}
+
+func TestFileGenerator_DatabaseFiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *cli.Config
+		expectedRepo File
+		expectedDB   string
+	}{
+		{
+			name:         "postgres by default",
+			config:       &cli.Config{ModuleName: "github.com/test/svc"},
+			expectedRepo: File{Path: "internal/infrastructure/postgres/postgres.go", Package: "postgres", TemplateName: "postgres_repository.tmpl"},
+			expectedDB:   "db.tmpl",
+		},
+		{
+			name:         "postgres with pgx",
+			config:       &cli.Config{ModuleName: "github.com/test/svc", DB: cli.DBPostgres, UsePgx: true},
+			expectedRepo: File{Path: "internal/infrastructure/postgres/postgres.go", Package: "postgres", TemplateName: "pgx_repository.tmpl"},
+			expectedDB:   "pgx_db.tmpl",
+		},
+		{
+			name:         "postgres with pgx and sqlc",
+			config:       &cli.Config{ModuleName: "github.com/test/svc", UsePgx: true, UseSQLC: true},
+			expectedRepo: File{Path: "internal/infrastructure/postgres/postgres.go", Package: "postgres", TemplateName: "sqlc_repository.tmpl"},
+			expectedDB:   "pgx_db.tmpl",
+		},
+		{
+			name:         "mysql",
+			config:       &cli.Config{ModuleName: "github.com/test/svc", DB: cli.DBMySQL},
+			expectedRepo: File{Path: "internal/infrastructure/mysql/mysql.go", Package: "mysql", TemplateName: "sql_repository.tmpl"},
+			expectedDB:   "db.tmpl",
+		},
+		{
+			name:         "mysql with gorm",
+			config:       &cli.Config{ModuleName: "github.com/test/svc", DB: cli.DBMySQL, ORM: cli.ORMGorm},
+			expectedRepo: File{Path: "internal/infrastructure/mysql/mysql.go", Package: "mysql", TemplateName: "gorm_repository.tmpl"},
+			expectedDB:   "gorm_db.tmpl",
+		},
+		{
+			name:         "mongo",
+			config:       &cli.Config{ModuleName: "github.com/test/svc", DB: cli.DBMongo},
+			expectedRepo: File{Path: "internal/infrastructure/mongo/mongo.go", Package: "mongo", TemplateName: "mongo_repository.tmpl"},
+			expectedDB:   "mongo_db.tmpl",
+		},
+		{
+			name:         "sqlite monolith",
+			config:       &cli.Config{ModuleName: "github.com/test/shop", DB: cli.DBSQLite, Monolith: true, Entities: []string{"product"}},
+			expectedRepo: File{Path: "internal/product/infrastructure/sqlite/sqlite.go", Package: "sqlite", TemplateName: "sql_repository.tmpl"},
+			expectedDB:   "db.tmpl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", tt.config)
+
+			var repo File
+			dbTemplate := ""
+			for _, file := range fg.getFileList("product") {
+				switch {
+				case file.Path == tt.expectedRepo.Path:
+					repo = file
+				case file.Path == "pkg/db/db.go":
+					dbTemplate = file.TemplateName
+				}
+			}
+
+			if repo != tt.expectedRepo {
+				t.Errorf("repository file = %+v, want %+v", repo, tt.expectedRepo)
+			}
+			if dbTemplate != tt.expectedDB {
+				t.Errorf("pkg/db/db.go template = %v, want %v", dbTemplate, tt.expectedDB)
+			}
+
+			data := fg.prepareTemplateData("main", "product")
+			if data.DBPackage != tt.expectedRepo.Package {
+				t.Errorf("DBPackage = %v, want %v", data.DBPackage, tt.expectedRepo.Package)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package scaffold
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		expected  string
+	}{
+		{
+			name:      "identical content",
+			existing:  "a\nb\n",
+			generated: "a\nb\n",
+			expected:  "--- f.go (existing)\n+++ f.go (generated)\n",
+		},
+		{
+			name:      "changed line",
+			existing:  "a\nb\nc\n",
+			generated: "a\nx\nc\n",
+			expected:  "--- f.go (existing)\n+++ f.go (generated)\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			name:      "distant changes split into hunks",
+			existing:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			generated: "0\n2\n3\n4\n5\n6\n7\n8\n9\n11\n",
+			expected: "--- f.go (existing)\n+++ f.go (generated)\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+11\n",
+		},
+		{
+			name:      "new file content",
+			existing:  "",
+			generated: "a\n",
+			expected:  "--- f.go (existing)\n+++ f.go (generated)\n@@ -1,0 +1,1 @@\n+a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("f.go", []byte(tt.existing), []byte(tt.generated))
+			if got != tt.expected {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
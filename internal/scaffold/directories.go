@@ -9,9 +9,11 @@ import (
 
 // DirectoryStructure defines the project directory structure
 type DirectoryStructure struct {
-	ProjectRoot string
-	EntityName  string
-	IsMonolith  bool
+	ProjectRoot  string
+	EntityName   string
+	IsMonolith   bool
+	// InfraPackage is the infrastructure package of the configured database; empty means postgres
+	InfraPackage string
 }
 
 // CreateDirectories creates the project directory structure
@@ -28,6 +30,13 @@ func (ds *DirectoryStructure) CreateDirectories() error {
 	return nil
 }
 
+// PlanDirectories records the directories CreateDirectories would create
+func (ds *DirectoryStructure) PlanDirectories(plan *Plan) {
+	for _, dir := range ds.getDirectories() {
+		plan.AddDir(dir)
+	}
+}
+
 // getDirectories returns the list of directories to create based on architecture type
 func (ds *DirectoryStructure) getDirectories() []string {
 	if ds.IsMonolith {
@@ -53,7 +62,8 @@ func (ds *DirectoryStructure) getStandardDirectories() []string {
 		"internal/domain/constants",
 		"internal/domain/repository",
 		"internal/infrastructure",
-		"internal/infrastructure/postgres",
+		"internal/infrastructure/" + ds.infraPackage(),
+		"internal/infrastructure/memory",
 		"migrations",
 		"config",
 		"pkg/logger",
@@ -114,8 +124,17 @@ func (ds *DirectoryStructure) getMonolithDirectories() []string {
 		
 		// Infrastructure layer - external concerns (database, external APIs)
 		entityPath + "/infrastructure",
-		entityPath + "/infrastructure/postgres",
+		entityPath + "/infrastructure/" + ds.infraPackage(),
+		entityPath + "/infrastructure/memory",
 	}
 	
 	return append(dirs, boundedContextDirs...)
 }
+
+// infraPackage returns the infrastructure package directory, defaulting to postgres
+func (ds *DirectoryStructure) infraPackage() string {
+	if ds.InfraPackage == "" {
+		return "postgres"
+	}
+	return ds.InfraPackage
+}
@@ -60,6 +60,7 @@ func TestDirectoryStructure_GetDirectories_Microservice(t *testing.T) {
 		"internal/domain/constants",
 		"internal/domain/repository",
 		"internal/infrastructure/postgres",
+		"internal/infrastructure/memory",
 		"migrations",
 	}
 
@@ -107,6 +108,7 @@ func TestDirectoryStructure_GetDirectories_Monolith(t *testing.T) {
 		"internal/user/application",
 		"internal/user/infrastructure",
 		"internal/user/infrastructure/postgres",
+		"internal/user/infrastructure/memory",
 	}
 
 	allExpectedDirs := append(expectedBaseDirs, expectedUserDirs...)
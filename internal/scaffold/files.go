@@ -3,44 +3,99 @@ package scaffold
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/openapi"
+	"github.com/indalyadav56/gogen/internal/schema"
 	"github.com/indalyadav56/gogen/internal/template"
+	"github.com/indalyadav56/gogen/utils"
 )
 
 type File struct {
 	Path         string
 	Package      string
 	TemplateName string
+	// Component names the optional component the file belongs to (empty for core files)
+	Component string
 }
 
 type FileGenerator struct {
 	renderer    *template.Renderer
 	projectRoot string
-	moduleName  string
-	isMonolith  bool
-	useGin      bool
-	useAuth     bool
-	entities    []string
+	config      *cli.Config
+	writer      *FileWriter
+	// migrationVersions caches the migration sequence number assigned to each entity
+	migrationVersions map[string]int
 }
 
-func NewFileGenerator(renderer *template.Renderer, projectRoot, moduleName string, isMonolith, useGin, useAuth bool, entities []string) *FileGenerator {
+func NewFileGenerator(renderer *template.Renderer, projectRoot string, config *cli.Config) *FileGenerator {
 	return &FileGenerator{
 		renderer:    renderer,
 		projectRoot: projectRoot,
-		moduleName:  moduleName,
-		isMonolith:  isMonolith,
-		useGin:      useGin,
-		useAuth:     useAuth,
-		entities:    entities,
+		config:      config,
+		writer:      NewFileWriter(config.OnConflict, os.Stdin, os.Stdout),
+		migrationVersions: make(map[string]int),
 	}
 }
 
+// Batch holds back the files generated from now on until Flush, which resolves conflicts once per
+// file against the content the run ends with
+func (fg *FileGenerator) Batch() {
+	fg.writer.Batch()
+}
+
+// Flush writes the files generated since Batch, applying the conflict policy
+func (fg *FileGenerator) Flush() error {
+	return fg.writer.Flush()
+}
+
+// Summary reports which files were created, overwritten, skipped or left unchanged
+func (fg *FileGenerator) Summary() WriteSummary {
+	return fg.writer.Summary()
+}
+
 func (fg *FileGenerator) GenerateFiles(entityName string) error {
-	files := fg.getFileList(entityName)
-	
+	return fg.generate(fg.getFileList(entityName), entityName)
+}
+
+// GenerateEntityFiles generates only the bounded context files of a single entity
+func (fg *FileGenerator) GenerateEntityFiles(entityName string) error {
+	return fg.generate(fg.getBoundedContextFiles(entityName), entityName)
+}
+
+// PlanFiles records the files GenerateFiles would create without touching the disk
+func (fg *FileGenerator) PlanFiles(plan *Plan, entityName string) {
+	for _, file := range fg.enabledFiles(fg.getFileList(entityName)) {
+		templateName := fg.resolveTemplate(file, entityName)
+		switch {
+		case templateName != "":
+			plan.AddFile(file.Path, templateName, "")
+		case file.Package != "":
+			plan.AddFile(file.Path, "", "package stub")
+		default:
+			plan.AddFile(file.Path, "", "empty file")
+		}
+	}
+}
+
+// enabledFiles filters out files belonging to disabled optional components
+func (fg *FileGenerator) enabledFiles(files []File) []File {
+	enabled := make([]File, 0, len(files))
 	for _, file := range files {
+		if file.Component != "" && !fg.config.HasComponent(file.Component) {
+			continue
+		}
+		enabled = append(enabled, file)
+	}
+	return enabled
+}
+
+// generate creates the given files, skipping disabled optional components
+func (fg *FileGenerator) generate(files []File, entityName string) error {
+	for _, file := range fg.enabledFiles(files) {
 		if err := fg.createFile(file, entityName); err != nil {
 			return fmt.Errorf("failed to create file %s: %w", file.Path, err)
 		}
@@ -50,7 +105,7 @@ func (fg *FileGenerator) GenerateFiles(entityName string) error {
 }
 
 func (fg *FileGenerator) getFileList(entityName string) []File {
-	if fg.isMonolith {
+	if fg.config.Monolith {
 		return fg.getMonolithFileList(entityName)
 	}
 	return fg.getMicroserviceFileList(entityName)
@@ -58,8 +113,9 @@ func (fg *FileGenerator) getFileList(entityName string) []File {
 
 func (fg *FileGenerator) getMicroserviceFileList(entityName string) []File {
 	files := []File{
-		{Path: "cmd/main.go", Package: "main", TemplateName: "main.tmpl"},
+		{Path: "cmd/main.go", Package: "main", TemplateName: fg.getMainTemplate()},
 		{Path: "config/config.go", Package: "config", TemplateName: "config.tmpl"},
+		{Path: ".env.example", Package: "", TemplateName: "env_example.tmpl"},
 		{Path: "pkg/logger/logger.go", Package: "logger", TemplateName: "logger.tmpl"},
 
 		// Domain
@@ -72,21 +128,38 @@ func (fg *FileGenerator) getMicroserviceFileList(entityName string) []File {
 		{Path: "internal/interface/http/v1/handlers/" + fmt.Sprintf("%s_handler.go", strings.ToLower(entityName)), Package: "handlers", TemplateName: fg.getHandlerTemplate()},
 		{Path: "internal/interface/http/middlewares/auth_middleware.go", Package: "middlewares"},
 
-		{Path: "internal/infrastructure/postgres/postgres.go", Package: "postgres", TemplateName: "postgres_repository.tmpl"},
+		{Path: "internal/infrastructure/" + fg.database().Package + "/" + fg.database().Package + ".go", Package: fg.database().Package, TemplateName: fg.database().RepositoryTemplate},
+		{Path: "internal/infrastructure/memory/memory.go", Package: "memory", TemplateName: "memory_repository.tmpl"},
 
 		// DTO
-		{Path: "internal/interface/http/v1/dto/request.go", Package: "dto", TemplateName: ""},
-		{Path: "internal/interface/http/v1/dto/response.go", Package: "dto", TemplateName: ""},
+		{Path: "internal/interface/http/v1/dto/request.go", Package: "dto", TemplateName: "request_dto.tmpl"},
+		{Path: "internal/interface/http/v1/dto/response.go", Package: "dto", TemplateName: "response_dto.tmpl"},
 
-		{Path: "pkg/db/db.go", Package: "db", TemplateName: "db.tmpl"},
+		{Path: "pkg/db/db.go", Package: "db", TemplateName: fg.database().DBTemplate},
+		{Path: "pkg/health/health.go", Package: "health", TemplateName: "health.tmpl"},
 
 		{Path: ".gitignore", Package: "", TemplateName: ""},
-		{Path: "Dockerfile", Package: "", TemplateName: "docker.tmpl"},
-		{Path: "Taskfile.yaml", Package: "", TemplateName: "taskfile.tmpl"},
+		{Path: "Dockerfile", Package: "", TemplateName: "docker.tmpl", Component: cli.ComponentDocker},
+		{Path: "Taskfile.yaml", Package: "", TemplateName: "taskfile.tmpl", Component: cli.ComponentTaskfile},
+	}
+	files = append(files, fg.framework().SupportFiles...)
+	files = append(files, fg.queryFiles()...)
+
+	if entityName != "" {
+		files = append(files, fg.migrationFiles(entityName)...)
+		files = append(files, fg.migrationRunnerFiles()...)
+		files = append(files, fg.sqlcFiles(entityName)...)
+		files = append(files, fg.sqlcConfigFiles()...)
+		files = append(files, docsFiles()...)
+		files = append(files, fg.grpcFiles(entityName)...)
+		files = append(files, fg.grpcServerFiles()...)
+		files = append(files, testFiles(entityName, "internal")...)
+		files = append(files, fg.integrationHarnessFiles()...)
+		files = append(files, fg.integrationFiles(entityName)...)
 	}
 
 	// Add auth-related files if UseAuth is enabled
-	if fg.useAuth {
+	if fg.config.UseAuth {
 		authFiles := []File{
 			// Auth entities
 			{Path: "internal/domain/entity/user.go", Package: "entity", TemplateName: "auth_user_entity.tmpl"},
@@ -122,10 +195,9 @@ func (fg *FileGenerator) getMicroserviceFileList(entityName string) []File {
 			{Path: "pkg/auth/password.go", Package: "auth", TemplateName: "auth_password.tmpl"},
 			{Path: "pkg/auth/rbac.go", Package: "auth", TemplateName: "auth_rbac.tmpl"},
 			
-			// Auth migrations
-			{Path: "migrations/001_create_auth_tables.sql", Package: "", TemplateName: "auth_migration.tmpl"},
 		}
 		files = append(files, authFiles...)
+		files = append(files, authMigrationFiles()...)
 	}
 
 	return files
@@ -137,8 +209,10 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 	files := []File{
 		{Path: "cmd/main.go", Package: "main", TemplateName: fg.getMainTemplate()},
 		{Path: "config/config.go", Package: "config", TemplateName: "config.tmpl"},
+		{Path: ".env.example", Package: "", TemplateName: "env_example.tmpl"},
 		{Path: "pkg/logger/logger.go", Package: "logger", TemplateName: "logger.tmpl"},
-		{Path: "pkg/db/db.go", Package: "db", TemplateName: "db.tmpl"},
+		{Path: "pkg/db/db.go", Package: "db", TemplateName: fg.database().DBTemplate},
+		{Path: "pkg/health/health.go", Package: "health", TemplateName: "health.tmpl"},
 		
 		// Shared components (non-auth related)
 		{Path: "internal/shared/dto/common.go", Package: "dto", TemplateName: ""},
@@ -146,7 +220,18 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 		{Path: "internal/shared/middleware/middleware.go", Package: "middleware", TemplateName: "auth_middleware.tmpl"},
 		
 		{Path: ".gitignore", Package: "", TemplateName: ""},
-		{Path: "Dockerfile", Package: "", TemplateName: "docker.tmpl"},
+		{Path: "Dockerfile", Package: "", TemplateName: "docker.tmpl", Component: cli.ComponentDocker},
+	}
+	files = append(files, fg.framework().SupportFiles...)
+	files = append(files, fg.queryFiles()...)
+	
+	// The migration runner, docs handler and gRPC server are rendered alongside cmd/main.go, which needs an entity
+	if entityName != "" {
+		files = append(files, fg.migrationRunnerFiles()...)
+		files = append(files, fg.sqlcConfigFiles()...)
+		files = append(files, docsFiles()...)
+		files = append(files, fg.grpcServerFiles()...)
+		files = append(files, fg.integrationHarnessFiles()...)
 	}
 	
 	// If no entity specified, create example bounded context
@@ -154,37 +239,15 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 		entityName = "example"
 	}
 	
-	// Create bounded context files for the entity
-	entityLower := strings.ToLower(entityName)
-	entityPath := fmt.Sprintf("internal/%s", entityLower)
-	
-	boundedContextFiles := []File{
-		// Domain layer - core business entities, value objects, aggregates
-		{Path: entityPath + "/domain/entity/entity.go", Package: "entity", TemplateName: "entity.tmpl"},
-		{Path: entityPath + "/domain/repository/repository.go", Package: "repository", TemplateName: "repository.tmpl"},
-		
-		// Interface layer - HTTP handlers, controllers (dynamic based on framework)
-		{Path: entityPath + "/interface/http/v1/handlers/" + fmt.Sprintf("%s_handler.go", strings.ToLower(entityName)), Package: "handlers", TemplateName: fg.getHandlerTemplate()},
-		{Path: entityPath + "/interface/http/v1/routes/routes.go", Package: "routes", TemplateName: fg.getRoutesTemplate()},
-		
-		// Application layer - application services, use cases
-		{Path: entityPath + "/application/" + fmt.Sprintf("%s_service.go", strings.ToLower(entityName)), Package: "application", TemplateName: "service.tmpl"},
-		
-		// Infrastructure layer - database implementations, external APIs
-		{Path: entityPath + "/infrastructure/postgres/postgres.go", Package: "postgres", TemplateName: "postgres_repository.tmpl"},
-		
-		// DTO
-		{Path: entityPath + "/interface/http/v1/dto/request.go", Package: "dto", TemplateName: ""},
-		{Path: entityPath + "/interface/http/v1/dto/response.go", Package: "dto", TemplateName: ""},
-	}
+	boundedContextFiles := fg.getBoundedContextFiles(entityName)
 	
 	// Add auth-related bounded contexts if UseAuth is enabled
-	if fg.useAuth {
+	if fg.config.UseAuth {
 		// JWT utilities in pkg
 		authUtilities := []File{
 			{Path: "pkg/auth/jwt.go", Package: "auth", TemplateName: "jwt_utils.tmpl"},
-			{Path: "migrations/000001_create_auth_tables.up.sql", Package: "", TemplateName: "auth_migration.tmpl"},
 		}
+		authUtilities = append(authUtilities, authMigrationFiles()...)
 		
 		// Auth bounded context (authentication/authorization logic)
 		authPath := "internal/auth"
@@ -216,7 +279,7 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 			{Path: userPath + "/interface/http/v1/dto/user_response.go", Package: "dto", TemplateName: "auth_response_dto.tmpl"},
 			
 			// Infrastructure layer
-			{Path: userPath + "/infrastructure/postgres/user_postgres.go", Package: "postgres", TemplateName: "user_postgres.tmpl"},
+			{Path: userPath + "/infrastructure/postgres/user_postgres.go", Package: "postgres", TemplateName: fg.authRepositoryTemplate("user")},
 		}
 		
 		// Role bounded context (role management)
@@ -236,7 +299,7 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 			{Path: rolePath + "/interface/http/v1/dto/role_response.go", Package: "dto", TemplateName: "auth_response_dto.tmpl"},
 			
 			// Infrastructure layer
-			{Path: rolePath + "/infrastructure/postgres/role_postgres.go", Package: "postgres", TemplateName: "role_postgres.tmpl"},
+			{Path: rolePath + "/infrastructure/postgres/role_postgres.go", Package: "postgres", TemplateName: fg.authRepositoryTemplate("role")},
 		}
 		
 		// Permission bounded context (permission management)
@@ -256,7 +319,7 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 			{Path: permissionPath + "/interface/http/v1/dto/permission_response.go", Package: "dto", TemplateName: "permission_dto.tmpl"},
 			
 			// Infrastructure layer
-			{Path: permissionPath + "/infrastructure/postgres/permission_postgres.go", Package: "postgres", TemplateName: "permission_postgres.tmpl"},
+			{Path: permissionPath + "/infrastructure/postgres/permission_postgres.go", Package: "postgres", TemplateName: fg.authRepositoryTemplate("permission")},
 		}
 		
 		// Combine all auth-related files
@@ -271,31 +334,79 @@ func (fg *FileGenerator) getMonolithFileList(entityName string) []File {
 	return append(files, boundedContextFiles...)
 }
 
-// getHandlerTemplate returns the appropriate handler template based on framework
-func (fg *FileGenerator) getHandlerTemplate() string {
-	if fg.useGin {
-		return "gin_handler.tmpl"
+// getBoundedContextFiles returns the per-entity files of a monolith bounded context
+func (fg *FileGenerator) getBoundedContextFiles(entityName string) []File {
+	entityLower := strings.ToLower(entityName)
+	entityPath := fmt.Sprintf("internal/%s", entityLower)
+	
+	files := []File{
+		// Domain layer - core business entities, value objects, aggregates
+		{Path: entityPath + "/domain/entity/entity.go", Package: "entity", TemplateName: "entity.tmpl"},
+		{Path: entityPath + "/domain/repository/repository.go", Package: "repository", TemplateName: "repository.tmpl"},
+		
+		// Interface layer - HTTP handlers, controllers (dynamic based on framework)
+		{Path: entityPath + "/interface/http/v1/handlers/" + fmt.Sprintf("%s_handler.go", strings.ToLower(entityName)), Package: "handlers", TemplateName: fg.getHandlerTemplate()},
+		{Path: entityPath + "/interface/http/v1/routes/routes.go", Package: "routes", TemplateName: fg.getRoutesTemplate()},
+		
+		// Application layer - application services, use cases
+		{Path: entityPath + "/application/" + fmt.Sprintf("%s_service.go", strings.ToLower(entityName)), Package: "application", TemplateName: "service.tmpl"},
+		
+		// Infrastructure layer - database implementations, external APIs
+		{Path: entityPath + "/infrastructure/" + fg.database().Package + "/" + fg.database().Package + ".go", Package: fg.database().Package, TemplateName: fg.database().RepositoryTemplate},
+		{Path: entityPath + "/infrastructure/memory/memory.go", Package: "memory", TemplateName: "memory_repository.tmpl"},
+		
+		// DTO
+		{Path: entityPath + "/interface/http/v1/dto/request.go", Package: "dto", TemplateName: "request_dto.tmpl"},
+		{Path: entityPath + "/interface/http/v1/dto/response.go", Package: "dto", TemplateName: "response_dto.tmpl"},
 	}
-	return "handler.tmpl"
+	
+	// gRPC transport - protobuf contract and server over the application service
+	files = append(files, fg.grpcFiles(entityName)...)
+	
+	// Tests - service and handler tests over a mock repository
+	files = append(files, testFiles(entityName, entityPath)...)
+	
+	// Integration tests - CRUD round trip of the database repository, run with -tags integration
+	files = append(files, fg.integrationFiles(entityName)...)
+	
+	// sqlc queries - compiled against the migrations into the shared sqlcdb package
+	files = append(files, fg.sqlcFiles(entityName)...)
+	
+	// Migrations - golang-migrate up/down pair creating the entity table
+	return append(files, fg.migrationFiles(entityName)...)
 }
 
-// getRoutesTemplate returns the appropriate routes template based on framework
+// getHandlerTemplate returns the handler template of the configured framework
+func (fg *FileGenerator) getHandlerTemplate() string {
+	return fg.framework().HandlerTemplate
+}
+
+// getRoutesTemplate returns the routes template of the configured framework
 func (fg *FileGenerator) getRoutesTemplate() string {
-	if fg.useGin {
-		return "gin_routes.tmpl"
-	}
-	return "routes.tmpl"
+	return fg.framework().RoutesTemplate
 }
 
-// getMainTemplate returns the appropriate main template based on framework and architecture
+// getMainTemplate returns the main template of the configured framework and architecture
 func (fg *FileGenerator) getMainTemplate() string {
-	if fg.isMonolith {
-		if fg.useGin {
-			return "gin_monolith_main.tmpl"
-		}
-		return "monolith_main.tmpl"
+	if fg.config.Monolith {
+		return fg.framework().MonolithMainTemplate
+	}
+	return fg.framework().MainTemplate
+}
+
+// entityKeys camel-cases entity names the way the generated directories and identifiers use them
+func entityKeys(entities []string) []string {
+	keys := make([]string, len(entities))
+	for i, entityName := range entities {
+		keys[i] = utils.ToCamelCase(entityName)
 	}
-	return "main.tmpl"
+	return keys
+}
+
+// TemplateData returns the data the files of entityName are rendered with, for templates rendered
+// outside the generator such as the cmd/main.go snippets of gogen add
+func (fg *FileGenerator) TemplateData(entityName string) template.Data {
+	return fg.prepareTemplateData("", entityName)
 }
 
 // prepareTemplateData creates template data with correct import paths based on architecture
@@ -303,45 +414,68 @@ func (fg *FileGenerator) prepareTemplateData(packageName, entityName string) tem
 	data := template.Data{
 		Package:     packageName,
 		ProjectRoot: fg.projectRoot,
-		ModuleName:  fg.moduleName,
+		ModuleName:  fg.config.ModuleName,
 		EntityName:  entityName,
-		Entities:    fg.entities,
-		IsMonolith:  fg.isMonolith,
-		UseGin:      fg.useGin,
-		UseAuth:     fg.useAuth,
+		Entities:    entityKeys(fg.config.Entities),
+		IsMonolith:  fg.config.Monolith,
+		UseGin:      fg.config.HTTPFramework() == cli.FrameworkGin,
+		Framework:   fg.config.HTTPFramework(),
+		DB:          fg.config.Database(),
+		DBPackage:   fg.database().Package,
+		UsePgx:      fg.config.UsePgx,
+		UseGORM:     fg.config.UseGORM(),
+		UseSQLC:     fg.config.UseSQLC,
+		SQLCDir:     fg.sqlcDir(),
+		Initialisms: schema.Initialisms(),
+		UseAuth:     fg.config.UseAuth,
+		UseMigrate:  fg.config.HasComponent(cli.ComponentMigrate) && fg.config.SQLDatabase(),
+		Fields:      fg.config.Fields[entityName],
+		TableName:   schema.TableName(entityName),
+		RoutePath:   openapi.RoutePath(fg.config, entityName),
+		UseSwagger:  fg.config.HasComponent(cli.ComponentSwagger),
+		UseGRPC:     fg.config.UseGRPC,
+		UseTests:    fg.config.HasComponent(cli.ComponentTests),
+		UseIntegration: fg.config.UseIntegration,
+		UseCursor: fg.config.UseCursor(),
+		DependentTables: fg.dependentTables(entityName),
+		ProtoImport: fg.config.ModuleName + "/" + path.Dir(protoPath(entityName)),
 	}
 	
-	if fg.isMonolith && entityName != "" {
+	if fg.config.Monolith && entityName != "" {
 		// Monolith bounded context import paths (clean architecture)
 		entityLower := strings.ToLower(entityName)
-		data.HandlerImport = fmt.Sprintf("%s/internal/%s/interface/http/v1/handlers", fg.moduleName, entityLower)
-		data.ServiceImport = fmt.Sprintf("%s/internal/%s/application", fg.moduleName, entityLower)
-		data.RepositoryImport = fmt.Sprintf("%s/internal/%s/domain/repository", fg.moduleName, entityLower)
-		data.EntityImport = fmt.Sprintf("%s/internal/%s/domain/entity", fg.moduleName, entityLower)
-		data.InfraImport = fmt.Sprintf("%s/internal/%s/infrastructure", fg.moduleName, entityLower)
-		data.RoutesImport = fmt.Sprintf("%s/internal/%s/interface/http/v1/routes", fg.moduleName, entityLower)
+		data.HandlerImport = fmt.Sprintf("%s/internal/%s/interface/http/v1/handlers", fg.config.ModuleName, entityLower)
+		data.ServiceImport = fmt.Sprintf("%s/internal/%s/application", fg.config.ModuleName, entityLower)
+		data.RepositoryImport = fmt.Sprintf("%s/internal/%s/domain/repository", fg.config.ModuleName, entityLower)
+		data.EntityImport = fmt.Sprintf("%s/internal/%s/domain/entity", fg.config.ModuleName, entityLower)
+		data.InfraImport = fmt.Sprintf("%s/internal/%s/infrastructure/%s", fg.config.ModuleName, entityLower, data.DBPackage)
+		data.RoutesImport = fmt.Sprintf("%s/internal/%s/interface/http/v1/routes", fg.config.ModuleName, entityLower)
+		data.DTOImport = fmt.Sprintf("%s/internal/%s/interface/http/v1/dto", fg.config.ModuleName, entityLower)
+		data.GRPCImport = fmt.Sprintf("%s/internal/%s/interface/grpc", fg.config.ModuleName, entityLower)
 	} else {
 		// Microservice import paths (default)
-		data.HandlerImport = fmt.Sprintf("%s/internal/interface/http/v1/handlers", fg.moduleName)
-		data.ServiceImport = fmt.Sprintf("%s/internal/application", fg.moduleName)
-		data.RepositoryImport = fmt.Sprintf("%s/internal/domain/repository", fg.moduleName)
-		data.EntityImport = fmt.Sprintf("%s/internal/domain/entity", fg.moduleName)
-		data.InfraImport = fmt.Sprintf("%s/internal/infrastructure/postgres", fg.moduleName)
-		data.RoutesImport = fmt.Sprintf("%s/internal/interface/http/v1/routes", fg.moduleName)
+		data.HandlerImport = fmt.Sprintf("%s/internal/interface/http/v1/handlers", fg.config.ModuleName)
+		data.ServiceImport = fmt.Sprintf("%s/internal/application", fg.config.ModuleName)
+		data.RepositoryImport = fmt.Sprintf("%s/internal/domain/repository", fg.config.ModuleName)
+		data.EntityImport = fmt.Sprintf("%s/internal/domain/entity", fg.config.ModuleName)
+		data.InfraImport = fmt.Sprintf("%s/internal/infrastructure/%s", fg.config.ModuleName, data.DBPackage)
+		data.RoutesImport = fmt.Sprintf("%s/internal/interface/http/v1/routes", fg.config.ModuleName)
+		data.DTOImport = fmt.Sprintf("%s/internal/interface/http/v1/dto", fg.config.ModuleName)
+		data.GRPCImport = fmt.Sprintf("%s/internal/interface/grpc", fg.config.ModuleName)
 	}
 	
 	// For auth-related templates in monolith mode, set specific import paths for separate bounded contexts
-	if fg.isMonolith && fg.useAuth {
+	if fg.config.Monolith && fg.config.UseAuth {
 		// Auth service needs to import from user, role, and permission bounded contexts
-		data.UserEntityImport = fmt.Sprintf("%s/internal/user/domain/entity", fg.moduleName)
-		data.UserRepositoryImport = fmt.Sprintf("%s/internal/user/domain/repository", fg.moduleName)
-		data.RoleEntityImport = fmt.Sprintf("%s/internal/role/domain/entity", fg.moduleName)
-		data.RoleRepositoryImport = fmt.Sprintf("%s/internal/role/domain/repository", fg.moduleName)
-		data.PermissionEntityImport = fmt.Sprintf("%s/internal/permission/domain/entity", fg.moduleName)
-		data.PermissionRepositoryImport = fmt.Sprintf("%s/internal/permission/domain/repository", fg.moduleName)
-		data.AuthServiceImport = fmt.Sprintf("%s/internal/auth/application", fg.moduleName)
-		data.UserServiceImport = fmt.Sprintf("%s/internal/user/application", fg.moduleName)
-		data.RoleServiceImport = fmt.Sprintf("%s/internal/role/application", fg.moduleName)
+		data.UserEntityImport = fmt.Sprintf("%s/internal/user/domain/entity", fg.config.ModuleName)
+		data.UserRepositoryImport = fmt.Sprintf("%s/internal/user/domain/repository", fg.config.ModuleName)
+		data.RoleEntityImport = fmt.Sprintf("%s/internal/role/domain/entity", fg.config.ModuleName)
+		data.RoleRepositoryImport = fmt.Sprintf("%s/internal/role/domain/repository", fg.config.ModuleName)
+		data.PermissionEntityImport = fmt.Sprintf("%s/internal/permission/domain/entity", fg.config.ModuleName)
+		data.PermissionRepositoryImport = fmt.Sprintf("%s/internal/permission/domain/repository", fg.config.ModuleName)
+		data.AuthServiceImport = fmt.Sprintf("%s/internal/auth/application", fg.config.ModuleName)
+		data.UserServiceImport = fmt.Sprintf("%s/internal/user/application", fg.config.ModuleName)
+		data.RoleServiceImport = fmt.Sprintf("%s/internal/role/application", fg.config.ModuleName)
 	}
 	
 	return data
@@ -361,10 +495,30 @@ func (fg *FileGenerator) createFile(file File, entityName string) error {
 	templateData := fg.prepareTemplateData(file.Package, entityName)
 	
 	// If a template is specified, render it
-	if file.TemplateName != "" && entityName != "" {
-		templatePath := "templates/" + file.TemplateName
-		return fg.renderer.RenderToFile(templatePath, fullPath, templateData)
-	} else if file.TemplateName == "db.tmpl" || file.TemplateName == "logger.tmpl" || 
+	content := stubContent(file)
+	if templateName := fg.resolveTemplate(file, entityName); templateName != "" {
+		rendered, err := fg.renderer.Render("templates/"+templateName, templateData)
+		if err != nil {
+			return err
+		}
+		content = rendered
+	}
+	
+	// Existing files are handled according to the conflict policy
+	return fg.writer.Write(fullPath, content)
+}
+
+// resolveTemplate returns the template used to render a file, or "" when a stub is written instead
+func (fg *FileGenerator) resolveTemplate(file File, entityName string) string {
+	if file.TemplateName == "" {
+		return ""
+	}
+	// entity repositories render one entity's table; without an entity the file is a stub
+	if entityName == "" && fg.isEntityRepositoryTemplate(file.TemplateName) {
+		return ""
+	}
+	if entityName != "" || file.TemplateName == fg.database().DBTemplate || file.TemplateName == "logger.tmpl" ||
+		file.TemplateName == "config.tmpl" || file.TemplateName == "env_example.tmpl" ||
 		strings.HasPrefix(file.TemplateName, "auth_") ||
 		strings.HasSuffix(file.TemplateName, "_entity.tmpl") ||
 		strings.HasSuffix(file.TemplateName, "_service.tmpl") ||
@@ -372,13 +526,23 @@ func (fg *FileGenerator) createFile(file File, entityName string) error {
 		strings.HasSuffix(file.TemplateName, "_repository.tmpl") ||
 		strings.HasSuffix(file.TemplateName, "_postgres.tmpl") ||
 		file.TemplateName == "jwt_utils.tmpl" {
-		templatePath := "templates/" + file.TemplateName
-		return fg.renderer.RenderToFile(templatePath, fullPath, templateData)
-	} else {
-		content := fmt.Sprintf("package %s\n", file.Package)
-		if file.Package == "" {
-			return os.WriteFile(fullPath, nil, 0644)
-		}
-		return os.WriteFile(fullPath, []byte(content), 0644)
+		return file.TemplateName
+	}
+	return ""
+}
+
+// isEntityRepositoryTemplate reports whether a template renders the repository
+// of a single entity, as opposed to an auth bounded context's repository
+func (fg *FileGenerator) isEntityRepositoryTemplate(templateName string) bool {
+	return templateName == fg.database().RepositoryTemplate ||
+		templateName == "memory_repository.tmpl" ||
+		templateName == "mock_repository.tmpl"
+}
+
+// stubContent returns the content written for files without a template
+func stubContent(file File) []byte {
+	if file.Package == "" {
+		return nil
 	}
+	return []byte(fmt.Sprintf("package %s\n", file.Package))
 }
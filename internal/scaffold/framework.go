@@ -0,0 +1,70 @@
+package scaffold
+
+import "github.com/indalyadav56/gogen/internal/cli"
+
+// Framework is the template set that renders the HTTP layer for one router;
+// supporting a new framework means adding its templates and an entry to frameworks
+type Framework struct {
+	Name string
+	// HandlerTemplate renders an entity's handlers
+	HandlerTemplate string
+	// RoutesTemplate renders the Setup<Entity>Routes function mounting the handlers
+	RoutesTemplate string
+	// MainTemplate renders cmd/main.go for a microservice
+	MainTemplate string
+	// MonolithMainTemplate renders cmd/main.go for a monolith
+	MonolithMainTemplate string
+	// SupportFiles are project-wide packages the framework's templates import
+	SupportFiles []File
+}
+
+// frameworks holds the template set of every framework in cli.KnownFrameworks
+var frameworks = map[string]Framework{
+	cli.FrameworkChi: {
+		Name:                 cli.FrameworkChi,
+		HandlerTemplate:      "handler.tmpl",
+		RoutesTemplate:       "routes.tmpl",
+		MainTemplate:         "main.tmpl",
+		MonolithMainTemplate: "monolith_main.tmpl",
+	},
+	cli.FrameworkGin: {
+		Name:                 cli.FrameworkGin,
+		HandlerTemplate:      "gin_handler.tmpl",
+		RoutesTemplate:       "gin_routes.tmpl",
+		MainTemplate:         "gin_main.tmpl",
+		MonolithMainTemplate: "gin_monolith_main.tmpl",
+	},
+	cli.FrameworkEcho: {
+		Name:                 cli.FrameworkEcho,
+		HandlerTemplate:      "echo_handler.tmpl",
+		RoutesTemplate:       "echo_routes.tmpl",
+		MainTemplate:         "echo_main.tmpl",
+		MonolithMainTemplate: "echo_main.tmpl",
+	},
+	cli.FrameworkFiber: {
+		Name:                 cli.FrameworkFiber,
+		HandlerTemplate:      "fiber_handler.tmpl",
+		RoutesTemplate:       "fiber_routes.tmpl",
+		MainTemplate:         "fiber_main.tmpl",
+		MonolithMainTemplate: "fiber_main.tmpl",
+	},
+	cli.FrameworkStdlib: {
+		Name:                 cli.FrameworkStdlib,
+		HandlerTemplate:      "stdlib_handler.tmpl",
+		RoutesTemplate:       "stdlib_routes.tmpl",
+		MainTemplate:         "stdlib_main.tmpl",
+		MonolithMainTemplate: "stdlib_main.tmpl",
+		SupportFiles: []File{
+			// middleware chaining in place of a router's middleware stack
+			{Path: "pkg/middleware/middleware.go", Package: "middleware", TemplateName: "stdlib_middleware.tmpl"},
+		},
+	},
+}
+
+// framework returns the template set of the configured framework, falling back to chi
+func (fg *FileGenerator) framework() Framework {
+	if f, ok := frameworks[fg.config.HTTPFramework()]; ok {
+		return f
+	}
+	return frameworks[cli.FrameworkChi]
+}
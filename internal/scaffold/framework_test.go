@@ -0,0 +1,117 @@
+package scaffold
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFrameworks_CoverKnownFrameworks(t *testing.T) {
+	for _, name := range cli.KnownFrameworks {
+		f, ok := frameworks[name]
+		if !ok {
+			t.Errorf("framework %q has no template set", name)
+			continue
+		}
+		if f.Name != name {
+			t.Errorf("frameworks[%q].Name = %q", name, f.Name)
+		}
+		templates := []string{f.HandlerTemplate, f.RoutesTemplate, f.MainTemplate, f.MonolithMainTemplate}
+		for _, file := range f.SupportFiles {
+			templates = append(templates, file.TemplateName)
+		}
+		for _, tmpl := range templates {
+			if _, err := os.Stat(filepath.Join("..", "..", "templates", tmpl)); err != nil {
+				t.Errorf("framework %q: template %s: %v", name, tmpl, err)
+			}
+		}
+	}
+}
+
+func TestFileGenerator_FrameworkTemplates(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *cli.Config
+		expectedMain string
+		expectedHTTP [2]string
+	}{
+		{
+			name:         "chi by default",
+			config:       &cli.Config{},
+			expectedMain: "main.tmpl",
+			expectedHTTP: [2]string{"handler.tmpl", "routes.tmpl"},
+		},
+		{
+			name:         "gin microservice",
+			config:       &cli.Config{UseGin: true},
+			expectedMain: "gin_main.tmpl",
+			expectedHTTP: [2]string{"gin_handler.tmpl", "gin_routes.tmpl"},
+		},
+		{
+			name:         "gin monolith",
+			config:       &cli.Config{Framework: cli.FrameworkGin, Monolith: true},
+			expectedMain: "gin_monolith_main.tmpl",
+			expectedHTTP: [2]string{"gin_handler.tmpl", "gin_routes.tmpl"},
+		},
+		{
+			name:         "echo monolith",
+			config:       &cli.Config{Framework: cli.FrameworkEcho, Monolith: true},
+			expectedMain: "echo_main.tmpl",
+			expectedHTTP: [2]string{"echo_handler.tmpl", "echo_routes.tmpl"},
+		},
+		{
+			name:         "fiber microservice",
+			config:       &cli.Config{Framework: cli.FrameworkFiber},
+			expectedMain: "fiber_main.tmpl",
+			expectedHTTP: [2]string{"fiber_handler.tmpl", "fiber_routes.tmpl"},
+		},
+		{
+			name:         "stdlib monolith",
+			config:       &cli.Config{Framework: cli.FrameworkStdlib, Monolith: true},
+			expectedMain: "stdlib_main.tmpl",
+			expectedHTTP: [2]string{"stdlib_handler.tmpl", "stdlib_routes.tmpl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", tt.config)
+
+			if got := fg.getMainTemplate(); got != tt.expectedMain {
+				t.Errorf("getMainTemplate() = %v, want %v", got, tt.expectedMain)
+			}
+			if got := fg.getHandlerTemplate(); got != tt.expectedHTTP[0] {
+				t.Errorf("getHandlerTemplate() = %v, want %v", got, tt.expectedHTTP[0])
+			}
+			if got := fg.getRoutesTemplate(); got != tt.expectedHTTP[1] {
+				t.Errorf("getRoutesTemplate() = %v, want %v", got, tt.expectedHTTP[1])
+			}
+		})
+	}
+}
+
+func TestFileGenerator_FrameworkSupportFiles(t *testing.T) {
+	for _, isMonolith := range []bool{false, true} {
+		var mockFS embed.FS
+		fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", &cli.Config{
+			Monolith:  isMonolith,
+			Framework: cli.FrameworkStdlib,
+			Entities:  []string{"user"},
+		})
+
+		found := false
+		for _, file := range fg.getFileList("user") {
+			if file.Path == "pkg/middleware/middleware.go" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("monolith=%v: missing stdlib middleware package", isMonolith)
+		}
+	}
+}
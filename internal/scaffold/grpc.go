@@ -0,0 +1,53 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/indalyadav56/gogen/utils"
+)
+
+// grpcFiles returns the protobuf contract and gRPC server of an entity when --grpc is set
+func (fg *FileGenerator) grpcFiles(entityName string) []File {
+	if !fg.config.UseGRPC || entityName == "" {
+		return nil
+	}
+
+	serverDir := "internal/interface/grpc"
+	if fg.config.Monolith {
+		serverDir = fmt.Sprintf("internal/%s/interface/grpc", strings.ToLower(entityName))
+	}
+
+	return []File{
+		{Path: protoPath(entityName), TemplateName: "grpc_proto.tmpl"},
+		{Path: fmt.Sprintf("%s/%s_server.go", serverDir, strings.ToLower(entityName)), Package: "grpc", TemplateName: "grpc_server.tmpl"},
+	}
+}
+
+// grpcServerFiles returns the shared gRPC server package started by cmd/main.go
+func (fg *FileGenerator) grpcServerFiles() []File {
+	if !fg.config.UseGRPC {
+		return nil
+	}
+	return []File{
+		{Path: "pkg/grpcserver/grpcserver.go", Package: "grpcserver", TemplateName: "grpcserver.tmpl"},
+	}
+}
+
+// ProtoFiles returns the .proto files generated for the configured entities, relative to the project root
+func (fg *FileGenerator) ProtoFiles() []string {
+	if !fg.config.UseGRPC {
+		return nil
+	}
+	files := make([]string, 0, len(fg.config.Entities))
+	for _, entityName := range fg.config.Entities {
+		files = append(files, protoPath(utils.ToCamelCase(entityName)))
+	}
+	return files
+}
+
+// protoPath returns the .proto file of an entity; protoc writes the Go stubs next to it
+func protoPath(entityName string) string {
+	entityLower := strings.ToLower(entityName)
+	return fmt.Sprintf("api/proto/%s/v1/%s.proto", entityLower, entityLower)
+}
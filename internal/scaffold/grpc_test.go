@@ -0,0 +1,79 @@
+package scaffold
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_GRPCFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		isMonolith bool
+		useGRPC    bool
+		expected   []string
+	}{
+		{
+			name:    "microservice",
+			useGRPC: true,
+			expected: []string{
+				"api/proto/user/v1/user.proto",
+				"internal/interface/grpc/user_server.go",
+				"pkg/grpcserver/grpcserver.go",
+			},
+		},
+		{
+			name:       "monolith",
+			isMonolith: true,
+			useGRPC:    true,
+			expected: []string{
+				"api/proto/user/v1/user.proto",
+				"internal/user/interface/grpc/user_server.go",
+				"pkg/grpcserver/grpcserver.go",
+			},
+		},
+		{
+			name:     "disabled",
+			useGRPC:  false,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(tt.isMonolith, false)
+			config.UseGRPC = tt.useGRPC
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			var found []string
+			for _, file := range fg.getFileList("user") {
+				if strings.HasPrefix(file.Path, "api/") || strings.Contains(file.Path, "grpc") {
+					found = append(found, file.Path)
+				}
+			}
+			sort.Strings(found)
+			if strings.Join(found, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("gRPC files = %v, want %v", found, tt.expected)
+			}
+
+			if tt.useGRPC {
+				if got := strings.Join(fg.ProtoFiles(), ","); got != "api/proto/user/v1/user.proto" {
+					t.Errorf("ProtoFiles() = %s", got)
+				}
+				data := fg.prepareTemplateData("grpc", "user")
+				if data.ProtoImport != "github.com/test/project/api/proto/user/v1" {
+					t.Errorf("ProtoImport = %s", data.ProtoImport)
+				}
+				if !strings.HasSuffix(data.GRPCImport, "interface/grpc") {
+					t.Errorf("GRPCImport = %s", data.GRPCImport)
+				}
+			} else if len(fg.ProtoFiles()) != 0 {
+				t.Errorf("ProtoFiles() = %v, want none", fg.ProtoFiles())
+			}
+		})
+	}
+}
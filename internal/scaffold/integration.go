@@ -0,0 +1,31 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// integrationHarnessFiles returns the TestMain of the build-tagged integration tests, which
+// migrates a real database for the repository tests next to it
+func (fg *FileGenerator) integrationHarnessFiles() []File {
+	if !fg.config.UseIntegration {
+		return nil
+	}
+	files := []File{
+		{Path: "test/integration/main_test.go", Package: "integration", TemplateName: "integration_main_test.tmpl"},
+	}
+	if fg.config.Monolith && fg.config.UseAuth {
+		files = append(files, File{Path: "test/integration/auth_repositories_test.go", Package: "integration", TemplateName: "integration_auth_test.tmpl"})
+	}
+	return files
+}
+
+// integrationFiles returns the CRUD round-trip test of an entity's database repository
+func (fg *FileGenerator) integrationFiles(entityName string) []File {
+	if !fg.config.UseIntegration || entityName == "" {
+		return nil
+	}
+	return []File{
+		{Path: fmt.Sprintf("test/integration/%s_repository_test.go", strings.ToLower(entityName)), Package: "integration", TemplateName: "integration_repository_test.tmpl"},
+	}
+}
@@ -0,0 +1,83 @@
+package scaffold
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_IntegrationFiles(t *testing.T) {
+	tests := []struct {
+		name           string
+		isMonolith     bool
+		useAuth        bool
+		useIntegration bool
+		expected       []string
+	}{
+		{
+			name:           "microservice",
+			useIntegration: true,
+			expected: []string{
+				"test/integration/main_test.go",
+				"test/integration/user_repository_test.go",
+			},
+		},
+		{
+			name:           "monolith with auth",
+			isMonolith:     true,
+			useAuth:        true,
+			useIntegration: true,
+			expected: []string{
+				"test/integration/auth_repositories_test.go",
+				"test/integration/main_test.go",
+				"test/integration/user_repository_test.go",
+			},
+		},
+		{
+			name:     "disabled",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(tt.isMonolith, false)
+			config.UseAuth = tt.useAuth
+			config.UseIntegration = tt.useIntegration
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			var found []string
+			for _, file := range fg.enabledFiles(fg.getFileList("user")) {
+				if strings.HasPrefix(file.Path, "test/integration/") {
+					found = append(found, file.Path)
+				}
+			}
+			sort.Strings(found)
+			if strings.Join(found, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("integration files = %v, want %v", found, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFileGenerator_IntegrationFiles_AddEntity(t *testing.T) {
+	var mockFS embed.FS
+	config := newTestConfig(true, false)
+	config.UseIntegration = true
+	fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+	// gogen add entity only renders the bounded context, which brings its own repository test
+	found := false
+	for _, file := range fg.getBoundedContextFiles("order") {
+		if file.Path == "test/integration/order_repository_test.go" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("bounded context files lack test/integration/order_repository_test.go")
+	}
+}
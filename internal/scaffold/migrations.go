@@ -0,0 +1,143 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/schema"
+)
+
+// authMigrationVersion is the sequence number reserved for the auth tables migration
+const authMigrationVersion = 1
+
+// migrationPattern matches golang-migrate file names, e.g. 000002_create_products.up.sql
+var migrationPattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// migrationFiles returns the up/down migration pair creating the table of an entity; document
+// databases get none
+func (fg *FileGenerator) migrationFiles(entityName string) []File {
+	if !fg.config.SQLDatabase() {
+		return nil
+	}
+	base := migrationBase(fg.migrationVersion(entityName), "create_"+schema.TableName(entityName))
+	return []File{
+		{Path: base + ".up.sql", TemplateName: "migration_up.tmpl"},
+		{Path: base + ".down.sql", TemplateName: "migration_down.tmpl"},
+	}
+}
+
+// authMigrationFiles returns the up/down migration pair creating the auth tables
+func authMigrationFiles() []File {
+	base := migrationBase(authMigrationVersion, "create_auth_tables")
+	return []File{
+		{Path: base + ".up.sql", TemplateName: "auth_migration.tmpl"},
+		{Path: base + ".down.sql", TemplateName: "auth_migration_down.tmpl"},
+	}
+}
+
+// migrationRunnerFiles returns the embedded migration runner component, which only SQL databases use
+func (fg *FileGenerator) migrationRunnerFiles() []File {
+	if !fg.config.SQLDatabase() {
+		return nil
+	}
+	return []File{
+		{Path: "migrations/migrations.go", Package: "migrations", TemplateName: "migrations_embed.tmpl", Component: cli.ComponentMigrate},
+		{Path: "pkg/migrate/migrate.go", Package: "migrate", TemplateName: "migrate.tmpl", Component: cli.ComponentMigrate},
+	}
+}
+
+// migrationBase returns the migration path without the direction suffix
+func migrationBase(version int, name string) string {
+	return fmt.Sprintf("migrations/%06d_%s", version, name)
+}
+
+// migrationVersion returns the sequence number of an entity's migration, reusing the number of an
+// existing migration for the same table and otherwise picking the next free one
+func (fg *FileGenerator) migrationVersion(entityName string) int {
+	if version, ok := fg.migrationVersions[entityName]; ok {
+		return version
+	}
+
+	existing, latest := fg.existingMigrations()
+	version, ok := existing["create_"+schema.TableName(entityName)]
+	if !ok {
+		// the tables an entity references must exist before its foreign keys are created; the spec
+		// loader rejects reference cycles
+		for _, field := range fg.config.Fields[entityName] {
+			if field.References != "" {
+				fg.migrationVersion(field.References)
+			}
+		}
+		if fg.config.UseAuth {
+			latest = max(latest, authMigrationVersion)
+		}
+		for _, assigned := range fg.migrationVersions {
+			latest = max(latest, assigned)
+		}
+		version = latest + 1
+	}
+
+	fg.migrationVersions[entityName] = version
+	return version
+}
+
+// dependentTables returns the tables whose rows reference those of entityName, directly or through
+// other tables, each before the tables it references, which is the order they can be cleared in
+func (fg *FileGenerator) dependentTables(entityName string) []string {
+	var tables []string
+	visited := map[string]bool{entityName: true}
+	var visit func(referenced string)
+	visit = func(referenced string) {
+		for _, other := range entityKeys(fg.config.Entities) {
+			if visited[other] || !referencesEntity(fg.config.Fields[other], referenced) {
+				continue
+			}
+			visited[other] = true
+			visit(other)
+			tables = append(tables, schema.TableName(other))
+		}
+	}
+	visit(entityName)
+	return tables
+}
+
+// referencesEntity reports whether one of fields is a foreign key to entityName
+func referencesEntity(fields schema.Fields, entityName string) bool {
+	for _, field := range fields {
+		if field.References == entityName {
+			return true
+		}
+	}
+	return false
+}
+
+// existingMigrations scans the project's migrations directory, returning the version of each
+// migration by name and the highest version in use
+func (fg *FileGenerator) existingMigrations() (map[string]int, int) {
+	versions := make(map[string]int)
+	latest := 0
+
+	entries, err := os.ReadDir(filepath.Join(fg.projectRoot, "migrations"))
+	if err != nil {
+		return versions, latest
+	}
+
+	for _, entry := range entries {
+		matches := migrationPattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+		versions[matches[2]] = version
+		latest = max(latest, version)
+	}
+
+	return versions, latest
+}
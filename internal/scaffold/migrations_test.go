@@ -0,0 +1,262 @@
+package scaffold
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	goembed "github.com/indalyadav56/gogen"
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_MigrationFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		useAuth  bool
+		existing []string
+		entities []string
+		expected map[string]string
+	}{
+		{
+			name:     "fresh project",
+			entities: []string{"product", "orderItem"},
+			expected: map[string]string{
+				"product":   "migrations/000001_create_products",
+				"orderItem": "migrations/000002_create_order_items",
+			},
+		},
+		{
+			name:     "auth reserves the first version",
+			useAuth:  true,
+			entities: []string{"product"},
+			expected: map[string]string{
+				"product": "migrations/000002_create_products",
+			},
+		},
+		{
+			name:     "entity added to existing project",
+			existing: []string{"000001_create_auth_tables.up.sql", "000002_create_products.up.sql", "000002_create_products.down.sql", "README.md"},
+			entities: []string{"invoice"},
+			expected: map[string]string{
+				"invoice": "migrations/000003_create_invoices",
+			},
+		},
+		{
+			name:     "existing migration of the same table is reused",
+			existing: []string{"000001_create_products.up.sql", "000002_create_invoices.up.sql"},
+			entities: []string{"product"},
+			expected: map[string]string{
+				"product": "migrations/000001_create_products",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if len(tt.existing) > 0 {
+				if err := os.MkdirAll(filepath.Join(root, "migrations"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, name := range tt.existing {
+				if err := os.WriteFile(filepath.Join(root, "migrations", name), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var mockFS embed.FS
+			config := newTestConfig(true, false)
+			config.UseAuth = tt.useAuth
+			fg := NewFileGenerator(template.NewRenderer(mockFS), root, config)
+
+			for _, entityName := range tt.entities {
+				files := fg.migrationFiles(entityName)
+				if len(files) != 2 {
+					t.Fatalf("migrationFiles(%q) returned %d files, want 2", entityName, len(files))
+				}
+				if files[0].Path != tt.expected[entityName]+".up.sql" || files[1].Path != tt.expected[entityName]+".down.sql" {
+					t.Errorf("migrationFiles(%q) = %s, %s, want %s.{up,down}.sql", entityName, files[0].Path, files[1].Path, tt.expected[entityName])
+				}
+			}
+
+			// Repeated calls for the same entity keep the assigned version
+			for _, entityName := range tt.entities {
+				if got := fg.migrationFiles(entityName)[0].Path; got != tt.expected[entityName]+".up.sql" {
+					t.Errorf("second migrationFiles(%q) = %s, want %s.up.sql", entityName, got, tt.expected[entityName])
+				}
+			}
+		})
+	}
+}
+
+func TestFileGenerator_MigrationRunnerFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []string
+		db         string
+		entityName string
+		expected   bool
+	}{
+		{name: "all components", components: nil, entityName: "product", expected: true},
+		{name: "migrate component disabled", components: []string{cli.ComponentDocker}, entityName: "product", expected: false},
+		{name: "no entity", components: nil, entityName: "", expected: false},
+		{name: "mongo has no migrations", components: nil, db: cli.DBMongo, entityName: "product", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(false, false)
+			config.Components = tt.components
+			config.DB = tt.db
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			found := map[string]bool{}
+			for _, file := range fg.enabledFiles(fg.getFileList(tt.entityName)) {
+				found[file.Path] = true
+			}
+
+			for _, path := range []string{"migrations/migrations.go", "pkg/migrate/migrate.go"} {
+				if found[path] != tt.expected {
+					t.Errorf("%s generated = %v, want %v", path, found[path], tt.expected)
+				}
+			}
+			if tt.db == cli.DBMongo && len(fg.migrationFiles(tt.entityName)) != 0 {
+				t.Errorf("migrationFiles(%q) = %v, want none", tt.entityName, fg.migrationFiles(tt.entityName))
+			}
+			wantMigrate := tt.components == nil && tt.db != cli.DBMongo
+			if data := fg.prepareTemplateData("main", tt.entityName); data.UseMigrate != wantMigrate {
+				t.Errorf("UseMigrate = %v, want %v", data.UseMigrate, wantMigrate)
+			}
+		})
+	}
+}
+
+func TestFileGenerator_MigrationRelations(t *testing.T) {
+	tests := []struct {
+		db       string
+		expected []string
+	}{
+		{
+			db: cli.DBPostgres,
+			expected: []string{
+				"order_id UUID,",
+				"CONSTRAINT fk_order_items_order_id FOREIGN KEY (order_id) REFERENCES orders(id)",
+				"CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);",
+			},
+		},
+		{
+			db: cli.DBSQLite,
+			expected: []string{
+				"order_id TEXT,",
+				"CONSTRAINT fk_order_items_order_id FOREIGN KEY (order_id) REFERENCES orders(id)",
+				"CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);",
+			},
+		},
+		{
+			db: cli.DBMySQL,
+			expected: []string{
+				"order_id CHAR(36),",
+				"KEY idx_order_items_order_id (order_id),",
+				"CONSTRAINT fk_order_items_order_id FOREIGN KEY (order_id) REFERENCES orders(id)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.db, func(t *testing.T) {
+			config := &cli.Config{
+				ModuleName: "github.com/test/project",
+				DB:         tt.db,
+				// declared before the entities they reference
+				Entities: []string{"order-item", "order", "customer"},
+				Fields: map[string]schema.Fields{
+					"customer":  {{Name: "email", Type: "string", Unique: true}},
+					"order":     {{Name: "customer_id", Type: "uuid", References: "customer"}},
+					"orderItem": {{Name: "order_id", Type: "uuid", References: "order"}, {Name: "quantity", Type: "int"}},
+				},
+			}
+			fg := NewFileGenerator(template.NewRenderer(goembed.TemplateFS), t.TempDir(), config)
+
+			// referenced tables are migrated first, whatever the declaration order
+			got := fg.migrationFiles("orderItem")[0].Path
+			if got != "migrations/000003_create_order_items.up.sql" {
+				t.Errorf("order_items migration = %s, want version 3 after customers and orders", got)
+			}
+			if got := fg.migrationFiles("customer")[0].Path; got != "migrations/000001_create_customers.up.sql" {
+				t.Errorf("customers migration = %s, want version 1", got)
+			}
+
+			// the optional relation column is nullable
+			rendered, err := fg.renderer.Render("templates/migration_up.tmpl", fg.prepareTemplateData("", "orderItem"))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(string(rendered), want) {
+					t.Errorf("migration lacks %q:\n%s", want, rendered)
+				}
+			}
+
+			if got := fg.dependentTables("customer"); !slices.Equal(got, []string{"order_items", "orders"}) {
+				t.Errorf("dependentTables(customer) = %v, want [order_items orders]", got)
+			}
+		})
+	}
+}
+
+func TestFileGenerator_MigrationNullableColumns(t *testing.T) {
+	tests := []struct {
+		db       string
+		expected []string
+	}{
+		{
+			db:       cli.DBPostgres,
+			expected: []string{"note VARCHAR(255) NOT NULL,", "placed_at TIMESTAMPTZ NOT NULL,", "shipped_at TIMESTAMPTZ,", "tracking_id UUID,"},
+		},
+		{
+			db:       cli.DBMySQL,
+			expected: []string{"note VARCHAR(255) NOT NULL,", "placed_at DATETIME(6) NOT NULL,", "shipped_at DATETIME(6),", "tracking_id CHAR(36),"},
+		},
+		{
+			db:       cli.DBSQLite,
+			expected: []string{"note TEXT NOT NULL,", "placed_at DATETIME NOT NULL,", "shipped_at DATETIME,", "tracking_id TEXT,"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.db, func(t *testing.T) {
+			config := &cli.Config{
+				ModuleName: "github.com/test/project",
+				DB:         tt.db,
+				Entities:   []string{"order"},
+				Fields: map[string]schema.Fields{
+					"order": {
+						{Name: "note", Type: "string"},
+						{Name: "placed_at", Type: "time", Required: true},
+						{Name: "shipped_at", Type: "time"},
+						{Name: "tracking_id", Type: "uuid", Unique: true},
+					},
+				},
+			}
+			fg := NewFileGenerator(template.NewRenderer(goembed.TemplateFS), t.TempDir(), config)
+
+			rendered, err := fg.renderer.Render("templates/migration_up.tmpl", fg.prepareTemplateData("", "order"))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			// optional UUIDs and times are stored as NULL, other optional fields as their zero value
+			for _, want := range tt.expected {
+				if !strings.Contains(string(rendered), want) {
+					t.Errorf("migration lacks %q:\n%s", want, rendered)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/openapi"
+)
+
+// OpenAPIPath is where the generated OpenAPI spec is written, relative to the project root
+const OpenAPIPath = "docs/api/openapi.yaml"
+
+// GenerateOpenAPI writes the OpenAPI spec describing every configured entity
+func (fg *FileGenerator) GenerateOpenAPI() error {
+	content, err := openapi.Build(fg.config).YAML()
+	if err != nil {
+		return fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+
+	fullPath := filepath.Join(fg.projectRoot, OpenAPIPath)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(fullPath), err)
+	}
+
+	return fg.writer.Write(fullPath, content)
+}
+
+// AddOpenAPIEntity documents entityName in the project's OpenAPI spec, updating the file in place
+// the way gogen add updates cmd/main.go. The spec keeps what it holds for the other entities, whose
+// fields are not detected; a project whose spec was removed gets a new one for every entity
+func (fg *FileGenerator) AddOpenAPIEntity(entityName string) error {
+	fullPath := filepath.Join(fg.projectRoot, OpenAPIPath)
+	doc, err := openapi.Load(fullPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		doc = openapi.Build(fg.config)
+	case err != nil:
+		return err
+	default:
+		doc.AddEntity(fg.config, entityName)
+	}
+
+	content, err := doc.YAML()
+	if err != nil {
+		return fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(fullPath), err)
+	}
+	if err := os.WriteFile(fullPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fullPath, err)
+	}
+	return nil
+}
+
+// PlanOpenAPI records the OpenAPI spec GenerateOpenAPI would write
+func (fg *FileGenerator) PlanOpenAPI(plan *Plan) {
+	plan.AddFile(OpenAPIPath, "", "OpenAPI spec")
+}
+
+// docsFiles returns the handler serving the spec and Swagger UI
+func docsFiles() []File {
+	return []File{
+		{Path: "docs/docs.go", Package: "docs", TemplateName: "docs.tmpl", Component: cli.ComponentSwagger},
+	}
+}
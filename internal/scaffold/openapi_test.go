@@ -0,0 +1,109 @@
+package scaffold
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_GenerateOpenAPI(t *testing.T) {
+	tests := []struct {
+		name     string
+		useGin   bool
+		expected string
+	}{
+		{name: "chi", useGin: false, expected: "/v1/user/{id}:"},
+		{name: "gin", useGin: true, expected: "/api/v1/users/{id}:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			var mockFS embed.FS
+			fg := NewFileGenerator(template.NewRenderer(mockFS), root, newTestConfig(false, tt.useGin))
+
+			if err := fg.GenerateOpenAPI(); err != nil {
+				t.Fatalf("GenerateOpenAPI() error = %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(root, OpenAPIPath))
+			if err != nil {
+				t.Fatalf("spec not written: %v", err)
+			}
+			if !strings.Contains(string(content), tt.expected) {
+				t.Errorf("spec missing path %s:\n%s", tt.expected, content)
+			}
+			if data := fg.prepareTemplateData("routes", "user"); !strings.Contains(tt.expected, data.RoutePath+"/") {
+				t.Errorf("RoutePath = %s does not match spec path %s", data.RoutePath, tt.expected)
+			}
+
+			// Regenerating an unchanged spec is not a conflict
+			if err := fg.GenerateOpenAPI(); err != nil {
+				t.Errorf("second GenerateOpenAPI() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFileGenerator_AddOpenAPIEntity(t *testing.T) {
+	root := t.TempDir()
+	var mockFS embed.FS
+	config := newTestConfig(true, false)
+	if err := NewFileGenerator(template.NewRenderer(mockFS), root, config).GenerateOpenAPI(); err != nil {
+		t.Fatalf("GenerateOpenAPI() error = %v", err)
+	}
+
+	// the spec differs from the one on disk, which gogen add updates rather than treating as a conflict
+	config.Entities = append(config.Entities, "order")
+	if err := NewFileGenerator(template.NewRenderer(mockFS), root, config).AddOpenAPIEntity("order"); err != nil {
+		t.Fatalf("AddOpenAPIEntity() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, OpenAPIPath))
+	if err != nil {
+		t.Fatalf("spec not written: %v", err)
+	}
+	for _, path := range []string{"/v1/user/{id}:", "/v1/order/{id}:"} {
+		if !strings.Contains(string(content), path) {
+			t.Errorf("spec missing path %s:\n%s", path, content)
+		}
+	}
+}
+
+func TestFileGenerator_DocsFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []string
+		expected   bool
+	}{
+		{name: "all components", components: nil, expected: true},
+		{name: "swagger component disabled", components: []string{cli.ComponentMigrate}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(true, false)
+			config.Components = tt.components
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			found := false
+			for _, file := range fg.enabledFiles(fg.getFileList("user")) {
+				if file.Path == "docs/docs.go" {
+					found = true
+				}
+			}
+			if found != tt.expected {
+				t.Errorf("docs/docs.go generated = %v, want %v", found, tt.expected)
+			}
+			if data := fg.prepareTemplateData("main", "user"); data.UseSwagger != tt.expected {
+				t.Errorf("UseSwagger = %v, want %v", data.UseSwagger, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Plan entry types
+const (
+	PlanDir  = "dir"
+	PlanFile = "file"
+)
+
+// PlanEntry is a single directory or file in a generation plan
+type PlanEntry struct {
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Template string `json:"template,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+// Plan lists the directories and files a generation run would create
+type Plan struct {
+	ProjectRoot string
+	entries     map[string]PlanEntry
+}
+
+// NewPlan creates an empty plan for the given project root
+func NewPlan(projectRoot string) *Plan {
+	return &Plan{
+		ProjectRoot: projectRoot,
+		entries:     make(map[string]PlanEntry),
+	}
+}
+
+// AddDir records a directory and its parents
+func (p *Plan) AddDir(dir string) {
+	dir = path.Clean(dir)
+	for dir != "." && dir != "/" {
+		if _, exists := p.entries[dir]; exists {
+			return
+		}
+		p.entries[dir] = PlanEntry{Path: dir, Type: PlanDir}
+		dir = path.Dir(dir)
+	}
+}
+
+// AddFile records a file; a later entry for the same path replaces the earlier one,
+// mirroring how generation overwrites files rendered for several entities
+func (p *Plan) AddFile(filePath, templateName, note string) {
+	filePath = path.Clean(filePath)
+	p.AddDir(path.Dir(filePath))
+	p.entries[filePath] = PlanEntry{Path: filePath, Type: PlanFile, Template: templateName, Note: note}
+}
+
+// Entries returns all plan entries sorted by path
+func (p *Plan) Entries() []PlanEntry {
+	entries := make([]PlanEntry, 0, len(p.entries))
+	for _, entry := range p.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries
+}
+
+// WriteJSON writes the plan as indented JSON with a stable ordering
+func (p *Plan) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		ProjectRoot string      `json:"project_root"`
+		Entries     []PlanEntry `json:"entries"`
+	}{
+		ProjectRoot: p.ProjectRoot,
+		Entries:     p.Entries(),
+	})
+}
+
+// WriteText writes the plan as a directory tree annotated with template names
+func (p *Plan) WriteText(w io.Writer) error {
+	children := make(map[string][]PlanEntry)
+	for _, entry := range p.Entries() {
+		parent := path.Dir(entry.Path)
+		children[parent] = append(children[parent], entry)
+	}
+
+	if _, err := fmt.Fprintf(w, "%s/\n", p.ProjectRoot); err != nil {
+		return err
+	}
+	return writeTree(w, children, ".", "")
+}
+
+// writeTree recursively prints the children of dir using box-drawing prefixes
+func writeTree(w io.Writer, children map[string][]PlanEntry, dir, prefix string) error {
+	entries := children[dir]
+	for i, entry := range entries {
+		branch, indent := "├── ", "│   "
+		if i == len(entries)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		line := prefix + branch + path.Base(entry.Path)
+		if entry.Type == PlanDir {
+			line += "/"
+		} else if source := entrySource(entry); source != "" {
+			line += "  ← " + source
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+
+		if entry.Type == PlanDir {
+			if err := writeTree(w, children, entry.Path, prefix+indent); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// entrySource describes where a planned file's content comes from
+func entrySource(entry PlanEntry) string {
+	var parts []string
+	if entry.Template != "" {
+		parts = append(parts, entry.Template)
+	}
+	if entry.Note != "" {
+		parts = append(parts, "("+entry.Note+")")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,104 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestPlan_AddFile(t *testing.T) {
+	plan := NewPlan("project")
+	plan.AddFile("cmd/main.go", "main.tmpl", "")
+	plan.AddFile("cmd/main.go", "monolith_main.tmpl", "")
+	plan.AddDir("internal/user/domain")
+
+	expected := []PlanEntry{
+		{Path: "cmd", Type: PlanDir},
+		{Path: "cmd/main.go", Type: PlanFile, Template: "monolith_main.tmpl"},
+		{Path: "internal", Type: PlanDir},
+		{Path: "internal/user", Type: PlanDir},
+		{Path: "internal/user/domain", Type: PlanDir},
+	}
+
+	entries := plan.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Entries() = %+v, want %+v", entries, expected)
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("Entries()[%d] = %+v, want %+v", i, entries[i], expected[i])
+		}
+	}
+}
+
+func TestPlan_WriteText(t *testing.T) {
+	plan := NewPlan("project")
+	plan.AddFile("cmd/main.go", "main.tmpl", "")
+	plan.AddFile(".gitignore", "", "empty file")
+	plan.AddDir("docs")
+
+	var buf bytes.Buffer
+	if err := plan.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	expected := `project/
+├── .gitignore  ← (empty file)
+├── cmd/
+│   └── main.go  ← main.tmpl
+└── docs/
+`
+	if buf.String() != expected {
+		t.Errorf("WriteText() = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestPlan_WriteJSON(t *testing.T) {
+	plan := NewPlan("project")
+	plan.AddFile("cmd/main.go", "main.tmpl", "")
+
+	var buf bytes.Buffer
+	if err := plan.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var decoded struct {
+		ProjectRoot string      `json:"project_root"`
+		Entries     []PlanEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON() produced invalid JSON: %v", err)
+	}
+	if decoded.ProjectRoot != "project" || len(decoded.Entries) != 2 {
+		t.Errorf("WriteJSON() = %s", buf.String())
+	}
+}
+
+func TestFileGenerator_PlanFiles(t *testing.T) {
+	var mockFS embed.FS
+	renderer := template.NewRenderer(mockFS)
+	config := newTestConfig(false, false)
+	config.Components = []string{}
+	fg := NewFileGenerator(renderer, t.TempDir(), config)
+
+	plan := NewPlan("project")
+	fg.PlanFiles(plan, "user")
+
+	found := make(map[string]PlanEntry)
+	for _, entry := range plan.Entries() {
+		found[entry.Path] = entry
+	}
+
+	if entry := found["internal/application/user_service.go"]; entry.Template != "service.tmpl" {
+		t.Errorf("user_service.go template = %q, want service.tmpl", entry.Template)
+	}
+	if entry := found["internal/domain/constants/constants.go"]; entry.Note != "package stub" {
+		t.Errorf("constants.go note = %q, want package stub", entry.Note)
+	}
+	if _, ok := found["Dockerfile"]; ok {
+		t.Error("Dockerfile planned although the docker component is disabled")
+	}
+}
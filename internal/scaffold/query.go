@@ -0,0 +1,18 @@
+package scaffold
+
+// queryFiles returns the shared pkg/query package the list endpoints parse their pagination, sorting
+// and filtering parameters with, plus the signed keyset cursors with --pagination=cursor. The SQL
+// repositories build their statements with it too, except for the entity repositories on sqlc,
+// which compile their list queries
+func (fg *FileGenerator) queryFiles() []File {
+	files := []File{
+		{Path: "pkg/query/query.go", Package: "query", TemplateName: "query.tmpl"},
+	}
+	if fg.config.UseCursor() {
+		files = append(files, File{Path: "pkg/query/cursor.go", Package: "query", TemplateName: "cursor.tmpl"})
+	}
+	if fg.config.SQLDatabase() && (!fg.config.UseSQLC || fg.config.UseAuth) {
+		files = append(files, File{Path: "pkg/query/sql.go", Package: "query", TemplateName: "query_sql.tmpl"})
+	}
+	return files
+}
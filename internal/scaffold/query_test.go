@@ -0,0 +1,79 @@
+package scaffold
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_QueryFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		isMonolith bool
+		db         string
+		useSQLC    bool
+		useAuth    bool
+		pagination string
+		expected   []string
+	}{
+		{
+			name:     "postgres",
+			expected: []string{"pkg/query/query.go", "pkg/query/sql.go"},
+		},
+		{
+			name:       "monolith on sqlite",
+			isMonolith: true,
+			db:         cli.DBSQLite,
+			expected:   []string{"pkg/query/query.go", "pkg/query/sql.go"},
+		},
+		{
+			name:       "cursor pagination",
+			pagination: cli.PaginationCursor,
+			expected:   []string{"pkg/query/cursor.go", "pkg/query/query.go", "pkg/query/sql.go"},
+		},
+		{
+			name:     "mongo",
+			db:       cli.DBMongo,
+			expected: []string{"pkg/query/query.go"},
+		},
+		{
+			name:     "sqlc",
+			useSQLC:  true,
+			expected: []string{"pkg/query/query.go"},
+		},
+		{
+			name:       "sqlc with auth repositories",
+			isMonolith: true,
+			useSQLC:    true,
+			useAuth:    true,
+			expected:   []string{"pkg/query/query.go", "pkg/query/sql.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(tt.isMonolith, false)
+			config.DB = tt.db
+			config.UseSQLC = tt.useSQLC
+			config.UseAuth = tt.useAuth
+			config.Pagination = tt.pagination
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			var found []string
+			for _, file := range fg.getFileList("user") {
+				if strings.HasPrefix(file.Path, "pkg/query/") {
+					found = append(found, file.Path)
+				}
+			}
+			sort.Strings(found)
+			if strings.Join(found, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("query files = %v, want %v", found, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package scaffold
+
+import (
+	"path"
+
+	"github.com/indalyadav56/gogen/internal/schema"
+	"github.com/indalyadav56/gogen/utils"
+)
+
+// sqlcFiles returns the sqlc queries of an entity when --sqlc is set
+func (fg *FileGenerator) sqlcFiles(entityName string) []File {
+	if !fg.config.UseSQLC || entityName == "" {
+		return nil
+	}
+	return []File{
+		{Path: sqlcQueriesPath(entityName), TemplateName: "sqlc_queries.tmpl"},
+	}
+}
+
+// sqlcConfigFiles returns the project-wide sqlc.yaml when --sqlc is set
+func (fg *FileGenerator) sqlcConfigFiles() []File {
+	if !fg.config.UseSQLC {
+		return nil
+	}
+	return []File{
+		{Path: "sqlc.yaml", TemplateName: "sqlc.tmpl"},
+	}
+}
+
+// sqlcDir returns the directory sqlc generates the sqlcdb package into; monolith bounded contexts share one
+func (fg *FileGenerator) sqlcDir() string {
+	if fg.config.Monolith {
+		return "internal/shared/sqlcdb"
+	}
+	return "internal/infrastructure/postgres/sqlcdb"
+}
+
+// SQLCOutputs returns the Go files `sqlc generate` writes for the configured entities, relative to the project root
+func (fg *FileGenerator) SQLCOutputs() []string {
+	if !fg.config.UseSQLC {
+		return nil
+	}
+	outputs := []string{path.Join(fg.sqlcDir(), "db.go"), path.Join(fg.sqlcDir(), "models.go")}
+	for _, entityName := range fg.config.Entities {
+		outputs = append(outputs, path.Join(fg.sqlcDir(), path.Base(sqlcQueriesPath(utils.ToCamelCase(entityName)))+".go"))
+	}
+	return outputs
+}
+
+// sqlcQueriesPath returns the query file of an entity, named after its table
+func sqlcQueriesPath(entityName string) string {
+	return "queries/" + schema.TableName(entityName) + ".sql"
+}
@@ -0,0 +1,74 @@
+package scaffold
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_SQLCFiles(t *testing.T) {
+	tests := []struct {
+		name            string
+		isMonolith      bool
+		useSQLC         bool
+		expected        []string
+		expectedOutputs []string
+	}{
+		{
+			name:            "microservice",
+			useSQLC:         true,
+			expected:        []string{"queries/users.sql", "sqlc.yaml"},
+			expectedOutputs: []string{"internal/infrastructure/postgres/sqlcdb/db.go", "internal/infrastructure/postgres/sqlcdb/models.go", "internal/infrastructure/postgres/sqlcdb/users.sql.go"},
+		},
+		{
+			name:            "monolith",
+			isMonolith:      true,
+			useSQLC:         true,
+			expected:        []string{"queries/users.sql", "sqlc.yaml"},
+			expectedOutputs: []string{"internal/shared/sqlcdb/db.go", "internal/shared/sqlcdb/models.go", "internal/shared/sqlcdb/users.sql.go"},
+		},
+		{
+			name:     "disabled",
+			useSQLC:  false,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(tt.isMonolith, false)
+			config.UseSQLC = tt.useSQLC
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			var found []string
+			repoTemplate := ""
+			for _, file := range fg.getFileList("user") {
+				if strings.HasPrefix(file.Path, "queries/") || strings.HasPrefix(file.Path, "sqlc") {
+					found = append(found, file.Path)
+				}
+				if strings.HasSuffix(file.Path, "postgres/postgres.go") {
+					repoTemplate = file.TemplateName
+				}
+			}
+			sort.Strings(found)
+			if strings.Join(found, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("sqlc files = %v, want %v", found, tt.expected)
+			}
+			if got := strings.Join(fg.SQLCOutputs(), ","); got != strings.Join(tt.expectedOutputs, ",") {
+				t.Errorf("SQLCOutputs() = %s, want %v", got, tt.expectedOutputs)
+			}
+
+			expectedTemplate := "postgres_repository.tmpl"
+			if tt.useSQLC {
+				expectedTemplate = "sqlc_repository.tmpl"
+			}
+			if repoTemplate != expectedTemplate {
+				t.Errorf("repository template = %s, want %s", repoTemplate, expectedTemplate)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+)
+
+// testFiles returns the mock repository and the service and handler tests of an entity whose
+// layers live under dir ("internal" for a microservice, "internal/<entity>" for a bounded context)
+func testFiles(entityName, dir string) []File {
+	if entityName == "" {
+		return nil
+	}
+
+	entityLower := strings.ToLower(entityName)
+	return []File{
+		{Path: fmt.Sprintf("%s/domain/repository/mocks/%s_repository.go", dir, entityLower), Package: "mocks", TemplateName: "mock_repository.tmpl", Component: cli.ComponentTests},
+		{Path: fmt.Sprintf("%s/application/%s_service_test.go", dir, entityLower), Package: "application", TemplateName: "service_test.tmpl", Component: cli.ComponentTests},
+		{Path: fmt.Sprintf("%s/interface/http/v1/handlers/%s_handler_test.go", dir, entityLower), Package: "handlers_test", TemplateName: "handler_test.tmpl", Component: cli.ComponentTests},
+	}
+}
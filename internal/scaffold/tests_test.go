@@ -0,0 +1,63 @@
+package scaffold
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/indalyadav56/gogen/internal/cli"
+	"github.com/indalyadav56/gogen/internal/template"
+)
+
+func TestFileGenerator_TestFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		isMonolith bool
+		components []string
+		expected   []string
+	}{
+		{
+			name: "microservice",
+			expected: []string{
+				"internal/application/user_service_test.go",
+				"internal/domain/repository/mocks/user_repository.go",
+				"internal/interface/http/v1/handlers/user_handler_test.go",
+			},
+		},
+		{
+			name:       "monolith",
+			isMonolith: true,
+			expected: []string{
+				"internal/user/application/user_service_test.go",
+				"internal/user/domain/repository/mocks/user_repository.go",
+				"internal/user/interface/http/v1/handlers/user_handler_test.go",
+			},
+		},
+		{
+			name:       "disabled",
+			components: []string{cli.ComponentDocker},
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockFS embed.FS
+			config := newTestConfig(tt.isMonolith, false)
+			config.Components = tt.components
+			fg := NewFileGenerator(template.NewRenderer(mockFS), "/test", config)
+
+			var found []string
+			for _, file := range fg.enabledFiles(fg.getFileList("user")) {
+				if strings.HasSuffix(file.Path, "_test.go") || strings.Contains(file.Path, "/mocks/") {
+					found = append(found, file.Path)
+				}
+			}
+			sort.Strings(found)
+			if strings.Join(found, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("test files = %v, want %v", found, tt.expected)
+			}
+		})
+	}
+}
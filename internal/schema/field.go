@@ -0,0 +1,574 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SQL dialects the generated migrations and repositories can target
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	SQLite   = "sqlite"
+)
+
+// Supported field types mapped to their Go, SQL (PostgreSQL, MySQL, SQLite), OpenAPI and protobuf representations
+var fieldTypes = map[string]struct {
+	goType        string
+	sqlType       string
+	mysqlType     string
+	sqliteType    string
+	openAPIType   string
+	openAPIFormat string
+	protoType     string
+}{
+	"string":  {"string", "VARCHAR(255)", "VARCHAR(255)", "TEXT", "string", "", "string"},
+	"text":    {"string", "TEXT", "TEXT", "TEXT", "string", "", "string"},
+	"int":     {"int", "INTEGER", "INT", "INTEGER", "integer", "int32", "int32"},
+	"int64":   {"int64", "BIGINT", "BIGINT", "INTEGER", "integer", "int64", "int64"},
+	"float":   {"float64", "DOUBLE PRECISION", "DOUBLE", "REAL", "number", "double", "double"},
+	"decimal": {"float64", "NUMERIC(12,2)", "DECIMAL(12,2)", "NUMERIC", "number", "decimal", "double"},
+	"bool":    {"bool", "BOOLEAN", "BOOLEAN", "BOOLEAN", "boolean", "", "bool"},
+	"time":    {"time.Time", "TIMESTAMPTZ", "DATETIME(6)", "DATETIME", "string", "date-time", "google.protobuf.Timestamp"},
+	"uuid":    {"string", "UUID", "CHAR(36)", "TEXT", "string", "uuid", "string"},
+}
+
+// Common initialisms kept upper case in Go identifiers
+var initialisms = map[string]bool{
+	"id":   true,
+	"url":  true,
+	"uri":  true,
+	"api":  true,
+	"ip":   true,
+	"uuid": true,
+	"sku":  true,
+}
+
+// fieldNamePattern matches the snake_case names usable as Go, JSON and SQL identifiers alike
+var fieldNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// Words reserved by PostgreSQL, MySQL or SQLite that would have to be quoted as column names;
+// the generated migrations and queries use column names as they are, so fields cannot take them
+var reservedWords = map[string]bool{
+	"all": true, "alter": true, "analyze": true, "and": true, "any": true, "as": true, "asc": true,
+	"between": true, "both": true, "by": true, "case": true, "cast": true, "check": true,
+	"collate": true, "column": true, "constraint": true, "create": true, "cross": true,
+	"current_date": true, "current_time": true, "current_timestamp": true, "current_user": true,
+	"default": true, "delete": true, "desc": true, "distinct": true, "do": true, "drop": true,
+	"else": true, "end": true, "exists": true, "false": true, "fetch": true, "for": true,
+	"foreign": true, "from": true, "full": true, "grant": true, "group": true, "having": true,
+	"in": true, "index": true, "inner": true, "insert": true, "intersect": true, "interval": true,
+	"into": true, "is": true, "join": true, "key": true, "leading": true, "left": true, "like": true,
+	"limit": true, "match": true, "natural": true, "not": true, "null": true, "offset": true,
+	"on": true, "only": true, "option": true, "or": true, "order": true, "outer": true,
+	"primary": true, "range": true, "rank": true, "references": true, "returning": true,
+	"right": true, "row": true, "rows": true, "select": true, "set": true, "some": true,
+	"table": true, "then": true, "to": true, "trailing": true, "true": true, "union": true,
+	"unique": true, "update": true, "user": true, "using": true, "values": true, "when": true,
+	"where": true, "window": true, "with": true,
+}
+
+// Go identifiers the generated entities and DTOs declare themselves, which fields cannot become
+var generatedGoNames = map[string]bool{
+	"ID":        true,
+	"CreatedAt": true,
+	"UpdatedAt": true,
+	"Validate":  true,
+	"ToEntity":  true,
+}
+
+// Field describes a single attribute of a generated entity
+type Field struct {
+	Name     string
+	Type     string
+	Required bool
+	Unique   bool
+	// References names the entity this field points to (foreign key), if any
+	References string
+}
+
+// Fields is an ordered list of entity fields
+type Fields []Field
+
+// ParseFieldSpec parses an "entity:name:type[:modifier...]" spec into its entity and field
+func ParseFieldSpec(spec string) (string, Field, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 3 {
+		return "", Field{}, fmt.Errorf("invalid field spec %q: expected entity:name:type[:modifier...]", spec)
+	}
+
+	entityName := strings.TrimSpace(parts[0])
+	if entityName == "" {
+		return "", Field{}, fmt.Errorf("invalid field spec %q: entity name is empty", spec)
+	}
+
+	field, err := NewField(parts[1], parts[2], parts[3:]...)
+	if err != nil {
+		return "", Field{}, fmt.Errorf("invalid field spec %q: %w", spec, err)
+	}
+
+	return entityName, field, nil
+}
+
+// NewField creates a validated field from its name, type and modifiers
+func NewField(name, fieldType string, modifiers ...string) (Field, error) {
+	field := Field{
+		Name: ToSnakeCase(strings.TrimSpace(name)),
+		Type: strings.ToLower(strings.TrimSpace(fieldType)),
+	}
+
+	if field.Name == "" {
+		return Field{}, fmt.Errorf("field name is empty")
+	}
+	if field.Name == "id" || field.Name == "created_at" || field.Name == "updated_at" {
+		return Field{}, fmt.Errorf("field %q is generated automatically", field.Name)
+	}
+	if !fieldNamePattern.MatchString(field.Name) {
+		return Field{}, fmt.Errorf("invalid field name %q: use letters, digits and underscores, not starting with a digit", field.Name)
+	}
+	if reservedWords[field.Name] {
+		return Field{}, fmt.Errorf("field name %q is a reserved SQL word", field.Name)
+	}
+	if generatedGoNames[field.GoName()] {
+		return Field{}, fmt.Errorf("field name %q becomes the Go identifier %s, which the generated code already declares", field.Name, field.GoName())
+	}
+	if _, ok := fieldTypes[field.Type]; !ok {
+		return Field{}, fmt.Errorf("unsupported type %q for field %q (supported: %s)", field.Type, field.Name, strings.Join(SupportedTypes(), ", "))
+	}
+
+	for _, modifier := range modifiers {
+		switch strings.ToLower(strings.TrimSpace(modifier)) {
+		case "required":
+			field.Required = true
+		case "unique":
+			field.Unique = true
+		case "optional", "":
+		default:
+			return Field{}, fmt.Errorf("unknown modifier %q for field %q", modifier, field.Name)
+		}
+	}
+
+	return field, nil
+}
+
+// SupportedTypes returns the list of supported field types in a stable order
+func SupportedTypes() []string {
+	return []string{"string", "text", "int", "int64", "float", "decimal", "bool", "time", "uuid"}
+}
+
+// TypeForOpenAPI returns the field type for an OpenAPI schema type and format; formats
+// without an exact match fall back to the first supported type of the same schema type
+func TypeForOpenAPI(openAPIType, format string) (string, bool) {
+	for _, name := range SupportedTypes() {
+		if t := fieldTypes[name]; t.openAPIType == openAPIType && t.openAPIFormat == format {
+			return name, true
+		}
+	}
+	for _, name := range SupportedTypes() {
+		if fieldTypes[name].openAPIType == openAPIType {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// GoName returns the exported Go identifier for the field
+func (f Field) GoName() string {
+	return ToPascalCase(f.Name)
+}
+
+// JSONName returns the JSON key for the field
+func (f Field) JSONName() string {
+	return f.Name
+}
+
+// Column returns the database column name for the field
+func (f Field) Column() string {
+	return f.Name
+}
+
+// GoType returns the Go type used for the field, a pointer for nullable fields
+func (f Field) GoType() string {
+	if f.Nullable() {
+		return "*" + f.ValueGoType()
+	}
+	return f.ValueGoType()
+}
+
+// ValueGoType returns the Go type of the field's values, which GoType points to for nullable fields
+func (f Field) ValueGoType() string {
+	return fieldTypes[f.Type].goType
+}
+
+// Nullable reports whether the field's column accepts NULL. Optional UUIDs and times have no
+// zero value their columns could hold: "" is not a UUID and the zero time is not a meaningful
+// timestamp, so an omitted value is stored as NULL instead
+func (f Field) Nullable() bool {
+	return !f.Required && (f.Type == "uuid" || f.Type == "time")
+}
+
+// SQLType returns the PostgreSQL column type for the field
+func (f Field) SQLType() string {
+	return fieldTypes[f.Type].sqlType
+}
+
+// SQLTypeFor returns the column type for the field in a SQL dialect; unknown dialects get PostgreSQL types
+func (f Field) SQLTypeFor(dialect string) string {
+	switch dialect {
+	case MySQL:
+		return fieldTypes[f.Type].mysqlType
+	case SQLite:
+		return fieldTypes[f.Type].sqliteType
+	default:
+		return f.SQLType()
+	}
+}
+
+// OpenAPIType returns the OpenAPI schema type and format (possibly empty) for the field
+func (f Field) OpenAPIType() (string, string) {
+	t := fieldTypes[f.Type]
+	return t.openAPIType, t.openAPIFormat
+}
+
+// ProtoType returns the protobuf type used for the field
+func (f Field) ProtoType() string {
+	return fieldTypes[f.Type].protoType
+}
+
+// ProtoGoName returns the Go identifier protoc-gen-go generates for the field,
+// e.g. OwnerId for owner_id; it differs from GoName for initialisms
+func (f Field) ProtoGoName() string {
+	var b strings.Builder
+	name := f.Name
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b.WriteByte('X')
+		case c == '_' && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z':
+			// The following lower case letter is upper-cased instead
+		case c >= 'a' && c <= 'z':
+			b.WriteByte(c - 'a' + 'A')
+			for ; i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'; i++ {
+				b.WriteByte(name[i+1])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// ValidateTag returns the value of the validate struct tag for the field
+func (f Field) ValidateTag() string {
+	if f.Required {
+		return "required"
+	}
+	return "omitempty"
+}
+
+// ZeroCheck returns a Go expression that is true when the given value is unset
+// Boolean fields have no meaningful unset value and should not be checked
+func (f Field) ZeroCheck(expr string) string {
+	switch f.ValueGoType() {
+	case "string":
+		return expr + ` == ""`
+	case "time.Time":
+		return expr + ".IsZero()"
+	default:
+		return expr + " == 0"
+	}
+}
+
+// SampleValue returns a Go expression holding a valid, non-zero value for the field,
+// used as fixture data by the generated tests
+func (f Field) SampleValue() string {
+	switch f.Type {
+	case "string", "text":
+		return fmt.Sprintf("%q", "sample "+f.Name)
+	case "uuid":
+		return `"7f1c8a52-3b4d-4e6f-9a0b-1c2d3e4f5a6b"`
+	case "int", "int64":
+		return "42"
+	case "float", "decimal":
+		return "9.99"
+	case "bool":
+		return "true"
+	case "time":
+		return "time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)"
+	default:
+		return fieldTypes[f.Type].goType + "{}"
+	}
+}
+
+// FixtureValue returns a Go expression holding a valid value for the field that differs for every
+// value of the int expression index, so seeded rows never collide on unique columns. Strings, UUIDs
+// and times are built by the fixture helpers of the generated integration tests; a reference
+// seeds a fresh row of the referenced entity instead, which its foreign key requires. Nullable
+// fields are left NULL on every other row
+func (f Field) FixtureValue(index string) string {
+	if f.Nullable() {
+		return fmt.Sprintf("fixtureOptional(%s, %s)", f.fixtureValue(index), index)
+	}
+	return f.fixtureValue(index)
+}
+
+// fixtureValue returns the expression FixtureValue builds for a set value of the field
+func (f Field) fixtureValue(index string) string {
+	if f.References != "" {
+		return f.References + "Reference()"
+	}
+	switch f.Type {
+	case "string", "text":
+		return fmt.Sprintf("fixtureString(%q, %s)", f.Name, index)
+	case "uuid":
+		return fmt.Sprintf("fixtureUUID(%s)", index)
+	case "int":
+		return index
+	case "int64":
+		return fmt.Sprintf("int64(%s)", index)
+	case "float", "decimal":
+		return fmt.Sprintf("float64(%s) + 0.5", index)
+	case "bool":
+		return fmt.Sprintf("%s%%2 == 0", index)
+	case "time":
+		return fmt.Sprintf("fixtureTime(%s)", index)
+	default:
+		return fieldTypes[f.Type].goType + "{}"
+	}
+}
+
+// ReferencedTable returns the table the field's foreign key points to, or "" for plain fields
+func (f Field) ReferencedTable() string {
+	if f.References == "" {
+		return ""
+	}
+	return TableName(f.References)
+}
+
+// KeysetIndexed reports whether cursor pagination indexes the field together with the ID, so a
+// list sorted by it can seek to its cursor. Unique fields are already indexed on their own, and
+// MySQL cannot index TEXT columns without a prefix length. Lists cannot be sorted by nullable
+// fields, whose NULLs the databases order differently
+func (f Field) KeysetIndexed() bool {
+	return !f.Unique && f.Type != "text" && !f.Nullable()
+}
+
+// QueryType returns the name of the generated query.Type constant the field is filtered and sorted as
+func (f Field) QueryType() string {
+	switch f.Type {
+	case "uuid":
+		return "UUID"
+	case "int":
+		return "Int"
+	case "int64":
+		return "Int64"
+	case "float", "decimal":
+		return "Float"
+	case "bool":
+		return "Bool"
+	case "time":
+		return "Time"
+	default:
+		return "String"
+	}
+}
+
+// Add appends field, rejecting a field whose Go identifier is already taken by another field
+func (fs Fields) Add(field Field) (Fields, error) {
+	for _, existing := range fs {
+		if existing.GoName() != field.GoName() {
+			continue
+		}
+		if existing.Name == field.Name {
+			return fs, fmt.Errorf("field %q is declared twice", field.Name)
+		}
+		return fs, fmt.Errorf("fields %q and %q both become the Go identifier %s", existing.Name, field.Name, field.GoName())
+	}
+	return append(fs, field), nil
+}
+
+// HasTime reports whether any field uses time.Time
+func (fs Fields) HasTime() bool {
+	for _, f := range fs {
+		if f.ValueGoType() == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRequiredTime reports whether any required field uses time.Time; optional times are nullable
+// and left unset by the sample values of the generated tests
+func (fs Fields) HasRequiredTime() bool {
+	for _, f := range fs {
+		if f.Required && f.Type == "time" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRequired reports whether any field needs a presence check on input
+func (fs Fields) HasRequired() bool {
+	for _, f := range fs {
+		if f.Required && f.Type != "bool" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUUID reports whether any field holds a UUID, which the request DTOs check the format of
+func (fs Fields) HasUUID() bool {
+	for _, f := range fs {
+		if f.Type == "uuid" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUnique reports whether any field carries a unique constraint
+func (fs Fields) HasUnique() bool {
+	for _, f := range fs {
+		if f.Unique {
+			return true
+		}
+	}
+	return false
+}
+
+// Columns returns the column names of all fields
+func (fs Fields) Columns() []string {
+	columns := make([]string, 0, len(fs))
+	for _, f := range fs {
+		columns = append(columns, f.Column())
+	}
+	return columns
+}
+
+// Placeholders returns the positional parameters for all fields, e.g. "$1, $2"
+func (fs Fields) Placeholders() string {
+	return fs.PlaceholdersFor(Postgres)
+}
+
+// PlaceholdersFor returns the parameters for all fields in a SQL dialect, e.g. "$1, $2" or "?, ?"
+func (fs Fields) PlaceholdersFor(dialect string) string {
+	placeholders := make([]string, 0, len(fs))
+	for i := range fs {
+		placeholders = append(placeholders, Placeholder(dialect, i+1))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// Assignments returns the SET clause for all fields, e.g. "name = $1, price = $2"
+func (fs Fields) Assignments() string {
+	return fs.AssignmentsFor(Postgres)
+}
+
+// AssignmentsFor returns the SET clause for all fields in a SQL dialect, e.g. "name = ?, price = ?"
+func (fs Fields) AssignmentsFor(dialect string) string {
+	assignments := make([]string, 0, len(fs))
+	for i, f := range fs {
+		assignments = append(assignments, f.Column()+" = "+Placeholder(dialect, i+1))
+	}
+	return strings.Join(assignments, ", ")
+}
+
+// NextPlaceholder returns the positional parameter following the field parameters
+func (fs Fields) NextPlaceholder() string {
+	return Placeholder(Postgres, len(fs)+1)
+}
+
+// Placeholder returns bind parameter n of a SQL dialect: $n for PostgreSQL, ? for MySQL and SQLite
+func Placeholder(dialect string, n int) string {
+	if dialect == MySQL || dialect == SQLite {
+		return "?"
+	}
+	return fmt.Sprintf("$%d", n)
+}
+
+// TableName returns the pluralized snake_case table name of an entity, e.g. "orderItem" -> "order_items"
+func TableName(entityName string) string {
+	name := ToSnakeCase(entityName)
+	switch {
+	case name == "":
+		return ""
+	case strings.HasSuffix(name, "y") && !strings.HasSuffix(name, "ay") && !strings.HasSuffix(name, "ey") &&
+		!strings.HasSuffix(name, "oy") && !strings.HasSuffix(name, "uy"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	case strings.HasSuffix(name, "s") || strings.HasSuffix(name, "x") ||
+		strings.HasSuffix(name, "ch") || strings.HasSuffix(name, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
+// Singular reverses the pluralization applied by TableName to a single word
+func Singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses") || strings.HasSuffix(word, "xes") ||
+		strings.HasSuffix(word, "ches") || strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss") || strings.HasSuffix(word, "us") || strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// ToSnakeCase converts camelCase, PascalCase and kebab-case names to snake_case
+func ToSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' &&
+				(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ToPascalCase converts snake_case, kebab-case and camelCase names to PascalCase
+func ToPascalCase(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(ToSnakeCase(s), "_") {
+		if part == "" {
+			continue
+		}
+		if initialisms[part] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// Initialisms returns the lower case initialisms ToPascalCase keeps upper case, sorted
+func Initialisms() []string {
+	names := make([]string, 0, len(initialisms))
+	for name := range initialisms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,528 @@
+package schema
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseFieldSpec(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           string
+		expectedEntity string
+		expected       Field
+		wantErr        bool
+	}{
+		{
+			name:           "simple field",
+			spec:           "product:name:string",
+			expectedEntity: "product",
+			expected:       Field{Name: "name", Type: "string"},
+		},
+		{
+			name:           "required and unique modifiers",
+			spec:           "product:sku:string:required:unique",
+			expectedEntity: "product",
+			expected:       Field{Name: "sku", Type: "string", Required: true, Unique: true},
+		},
+		{
+			name:           "camel case name is converted",
+			spec:           "order:unitPrice:decimal",
+			expectedEntity: "order",
+			expected:       Field{Name: "unit_price", Type: "decimal"},
+		},
+		{
+			name:    "missing type",
+			spec:    "product:name",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			spec:    "product:name:varchar",
+			wantErr: true,
+		},
+		{
+			name:    "unknown modifier",
+			spec:    "product:name:string:primary",
+			wantErr: true,
+		},
+		{
+			name:    "reserved field",
+			spec:    "product:id:uuid",
+			wantErr: true,
+		},
+		{
+			name:    "name starting with a digit",
+			spec:    "product:1st:string",
+			wantErr: true,
+		},
+		{
+			name:           "hyphen is converted",
+			spec:           "product:my-field:string",
+			expectedEntity: "product",
+			expected:       Field{Name: "my_field", Type: "string"},
+		},
+		{
+			name:    "name with punctuation",
+			spec:    "product:unit.price:decimal",
+			wantErr: true,
+		},
+		{
+			name:    "name with non-ASCII letters",
+			spec:    "product:größe:int",
+			wantErr: true,
+		},
+		{
+			name:    "reserved SQL word",
+			spec:    "product:order:int",
+			wantErr: true,
+		},
+		{
+			name:    "reserved SQL word in camel case",
+			spec:    "product:Group:string",
+			wantErr: true,
+		},
+		{
+			name:    "name of a generated method",
+			spec:    "product:validate:bool",
+			wantErr: true,
+		},
+		{
+			name:    "name of a generated conversion",
+			spec:    "product:to_entity:string",
+			wantErr: true,
+		},
+		{
+			name:    "name becoming the ID field",
+			spec:    "product:i_d:uuid",
+			wantErr: true,
+		},
+		{
+			name:           "leading underscore",
+			spec:           "product:_rank:int",
+			expectedEntity: "product",
+			expected:       Field{Name: "_rank", Type: "int"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entityName, field, err := ParseFieldSpec(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseFieldSpec(%q) expected error, got nil", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFieldSpec(%q) error = %v", tt.spec, err)
+			}
+			if entityName != tt.expectedEntity {
+				t.Errorf("entity = %v, want %v", entityName, tt.expectedEntity)
+			}
+			if field != tt.expected {
+				t.Errorf("field = %+v, want %+v", field, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFields_Add(t *testing.T) {
+	fields := Fields{{Name: "name", Type: "string"}, {Name: "owner_id", Type: "uuid"}}
+
+	tests := []struct {
+		name    string
+		field   Field
+		wantErr bool
+	}{
+		{name: "new name", field: Field{Name: "price", Type: "decimal"}},
+		{name: "same name", field: Field{Name: "name", Type: "int"}, wantErr: true},
+		{name: "same Go identifier", field: Field{Name: "owner_i_d", Type: "uuid"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fields.Add(tt.field)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Add(%q) expected error, got nil", tt.field.Name)
+				}
+				if len(got) != len(fields) {
+					t.Errorf("Add(%q) returned %d fields on error, want %d", tt.field.Name, len(got), len(fields))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add(%q) error = %v", tt.field.Name, err)
+			}
+			if len(got) != len(fields)+1 || got[len(got)-1] != tt.field {
+				t.Errorf("Add(%q) = %+v", tt.field.Name, got)
+			}
+		})
+	}
+}
+
+func TestField_Representations(t *testing.T) {
+	tests := []struct {
+		field       Field
+		goName      string
+		goType      string
+		sqlType     string
+		validateTag string
+		openAPI     string
+		protoType   string
+		protoGoName string
+	}{
+		{Field{Name: "name", Type: "string", Required: true}, "Name", "string", "VARCHAR(255)", "required", "string", "string", "Name"},
+		{Field{Name: "unit_price", Type: "decimal"}, "UnitPrice", "float64", "NUMERIC(12,2)", "omitempty", "number/decimal", "double", "UnitPrice"},
+		{Field{Name: "owner_id", Type: "uuid", Required: true}, "OwnerID", "string", "UUID", "required", "string/uuid", "string", "OwnerId"},
+		{Field{Name: "parent_id", Type: "uuid"}, "ParentID", "*string", "UUID", "omitempty", "string/uuid", "string", "ParentId"},
+		{Field{Name: "released_at", Type: "time", Required: true}, "ReleasedAt", "time.Time", "TIMESTAMPTZ", "required", "string/date-time", "google.protobuf.Timestamp", "ReleasedAt"},
+		{Field{Name: "shipped_at", Type: "time"}, "ShippedAt", "*time.Time", "TIMESTAMPTZ", "omitempty", "string/date-time", "google.protobuf.Timestamp", "ShippedAt"},
+		{Field{Name: "line_2", Type: "int"}, "Line2", "int", "INTEGER", "omitempty", "integer/int32", "int32", "Line_2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			if got := tt.field.GoName(); got != tt.goName {
+				t.Errorf("GoName() = %v, want %v", got, tt.goName)
+			}
+			if got := tt.field.GoType(); got != tt.goType {
+				t.Errorf("GoType() = %v, want %v", got, tt.goType)
+			}
+			if got := tt.field.SQLType(); got != tt.sqlType {
+				t.Errorf("SQLType() = %v, want %v", got, tt.sqlType)
+			}
+			if got := tt.field.ValidateTag(); got != tt.validateTag {
+				t.Errorf("ValidateTag() = %v, want %v", got, tt.validateTag)
+			}
+			openAPIType, format := tt.field.OpenAPIType()
+			if got := strings.TrimSuffix(openAPIType+"/"+format, "/"); got != tt.openAPI {
+				t.Errorf("OpenAPIType() = %v, want %v", got, tt.openAPI)
+			}
+			if got := tt.field.ProtoType(); got != tt.protoType {
+				t.Errorf("ProtoType() = %v, want %v", got, tt.protoType)
+			}
+			if got := tt.field.ProtoGoName(); got != tt.protoGoName {
+				t.Errorf("ProtoGoName() = %v, want %v", got, tt.protoGoName)
+			}
+		})
+	}
+}
+
+func TestField_SampleValue(t *testing.T) {
+	tests := []struct {
+		field    Field
+		expected string
+	}{
+		{Field{Name: "name", Type: "string"}, `"sample name"`},
+		{Field{Name: "owner_id", Type: "uuid"}, `"7f1c8a52-3b4d-4e6f-9a0b-1c2d3e4f5a6b"`},
+		{Field{Name: "stock", Type: "int64"}, "42"},
+		{Field{Name: "price", Type: "decimal"}, "9.99"},
+		{Field{Name: "active", Type: "bool"}, "true"},
+		{Field{Name: "released_at", Type: "time"}, "time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			if got := tt.field.SampleValue(); got != tt.expected {
+				t.Errorf("SampleValue() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestField_FixtureValue(t *testing.T) {
+	tests := []struct {
+		field    Field
+		expected string
+	}{
+		{Field{Name: "name", Type: "string"}, `fixtureString("name", i)`},
+		{Field{Name: "owner_id", Type: "uuid", Required: true}, "fixtureUUID(i)"},
+		{Field{Name: "parent_id", Type: "uuid"}, "fixtureOptional(fixtureUUID(i), i)"},
+		{Field{Name: "quantity", Type: "int"}, "i"},
+		{Field{Name: "stock", Type: "int64"}, "int64(i)"},
+		{Field{Name: "price", Type: "decimal"}, "float64(i) + 0.5"},
+		{Field{Name: "active", Type: "bool"}, "i%2 == 0"},
+		{Field{Name: "released_at", Type: "time", Required: true}, "fixtureTime(i)"},
+		{Field{Name: "shipped_at", Type: "time"}, "fixtureOptional(fixtureTime(i), i)"},
+		{Field{Name: "customer_id", Type: "uuid", Required: true, References: "orderItem"}, "orderItemReference()"},
+		{Field{Name: "vendor_id", Type: "uuid", References: "vendor"}, "fixtureOptional(vendorReference(), i)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			if got := tt.field.FixtureValue("i"); got != tt.expected {
+				t.Errorf("FixtureValue() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestField_Nullable(t *testing.T) {
+	tests := []struct {
+		field    Field
+		expected bool
+	}{
+		{Field{Name: "parent_id", Type: "uuid"}, true},
+		{Field{Name: "shipped_at", Type: "time"}, true},
+		{Field{Name: "owner_id", Type: "uuid", Required: true}, false},
+		{Field{Name: "released_at", Type: "time", Required: true}, false},
+		{Field{Name: "name", Type: "string"}, false},
+		{Field{Name: "quantity", Type: "int"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			if got := tt.field.Nullable(); got != tt.expected {
+				t.Errorf("Nullable() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestField_QueryType(t *testing.T) {
+	tests := []struct {
+		field    Field
+		expected string
+	}{
+		{Field{Name: "name", Type: "string"}, "String"},
+		{Field{Name: "bio", Type: "text"}, "String"},
+		{Field{Name: "owner_id", Type: "uuid"}, "UUID"},
+		{Field{Name: "quantity", Type: "int"}, "Int"},
+		{Field{Name: "stock", Type: "int64"}, "Int64"},
+		{Field{Name: "price", Type: "decimal"}, "Float"},
+		{Field{Name: "active", Type: "bool"}, "Bool"},
+		{Field{Name: "released_at", Type: "time"}, "Time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			if got := tt.field.QueryType(); got != tt.expected {
+				t.Errorf("QueryType() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestField_ReferencedTable(t *testing.T) {
+	if got := (Field{Name: "category_id", Type: "uuid", References: "category"}).ReferencedTable(); got != "categories" {
+		t.Errorf("ReferencedTable() = %q, want categories", got)
+	}
+	if got := (Field{Name: "owner_id", Type: "uuid"}).ReferencedTable(); got != "" {
+		t.Errorf("ReferencedTable() of a plain field = %q, want empty", got)
+	}
+}
+
+func TestField_KeysetIndexed(t *testing.T) {
+	tests := []struct {
+		field    Field
+		expected bool
+	}{
+		{Field{Name: "price", Type: "decimal"}, true},
+		{Field{Name: "sku", Type: "string", Unique: true}, false},
+		{Field{Name: "bio", Type: "text"}, false},
+		{Field{Name: "released_at", Type: "time", Required: true}, true},
+		{Field{Name: "shipped_at", Type: "time"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			if got := tt.field.KeysetIndexed(); got != tt.expected {
+				t.Errorf("KeysetIndexed() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTypeForOpenAPI(t *testing.T) {
+	tests := []struct {
+		openAPIType string
+		format      string
+		expected    string
+		ok          bool
+	}{
+		{"string", "", "string", true},
+		{"string", "date-time", "time", true},
+		{"string", "uuid", "uuid", true},
+		{"string", "email", "string", true},
+		{"integer", "int64", "int64", true},
+		{"integer", "", "int", true},
+		{"number", "decimal", "decimal", true},
+		{"number", "float", "float", true},
+		{"boolean", "", "bool", true},
+		{"object", "", "", false},
+		{"array", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.openAPIType+"/"+tt.format, func(t *testing.T) {
+			got, ok := TypeForOpenAPI(tt.openAPIType, tt.format)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("TypeForOpenAPI(%q, %q) = %q, %v, want %q, %v", tt.openAPIType, tt.format, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFields_Helpers(t *testing.T) {
+	fields := Fields{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "active", Type: "bool"},
+	}
+
+	if fields.HasTime() {
+		t.Error("HasTime() = true, want false")
+	}
+	if !append(fields, Field{Name: "shipped_at", Type: "time"}).HasTime() {
+		t.Error("HasTime() = false with an optional time field, want true")
+	}
+	if append(fields, Field{Name: "shipped_at", Type: "time"}).HasRequiredTime() {
+		t.Error("HasRequiredTime() = true with an optional time field, want false")
+	}
+	if !append(fields, Field{Name: "released_at", Type: "time", Required: true}).HasRequiredTime() {
+		t.Error("HasRequiredTime() = false with a required time field, want true")
+	}
+	if !fields.HasRequired() {
+		t.Error("HasRequired() = false, want true")
+	}
+	if fields.HasUUID() {
+		t.Error("HasUUID() = true, want false")
+	}
+	if !append(fields, Field{Name: "tracking_id", Type: "uuid"}).HasUUID() {
+		t.Error("HasUUID() = false with a uuid field, want true")
+	}
+	if fields.HasUnique() {
+		t.Error("HasUnique() = true, want false")
+	}
+	if !append(fields, Field{Name: "email", Type: "string", Unique: true}).HasUnique() {
+		t.Error("HasUnique() = false with a unique field, want true")
+	}
+
+	columns := fields.Columns()
+	if len(columns) != 2 || columns[0] != "name" || columns[1] != "active" {
+		t.Errorf("Columns() = %v, want [name active]", columns)
+	}
+	if got := fields.Placeholders(); got != "$1, $2" {
+		t.Errorf("Placeholders() = %q, want %q", got, "$1, $2")
+	}
+	if got := fields.Assignments(); got != "name = $1, active = $2" {
+		t.Errorf("Assignments() = %q, want %q", got, "name = $1, active = $2")
+	}
+	if got := fields.NextPlaceholder(); got != "$3" {
+		t.Errorf("NextPlaceholder() = %q, want %q", got, "$3")
+	}
+	if got := fields.PlaceholdersFor(MySQL); got != "?, ?" {
+		t.Errorf("PlaceholdersFor(mysql) = %q, want %q", got, "?, ?")
+	}
+	if got := fields.AssignmentsFor(SQLite); got != "name = ?, active = ?" {
+		t.Errorf("AssignmentsFor(sqlite) = %q, want %q", got, "name = ?, active = ?")
+	}
+}
+
+func TestField_SQLTypeFor(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		dialect   string
+		expected  string
+	}{
+		{"uuid", Postgres, "UUID"},
+		{"uuid", MySQL, "CHAR(36)"},
+		{"uuid", SQLite, "TEXT"},
+		{"time", MySQL, "DATETIME(6)"},
+		{"decimal", SQLite, "NUMERIC"},
+		{"float", "", "DOUBLE PRECISION"},
+	}
+
+	for _, tt := range tests {
+		field := Field{Name: "value", Type: tt.fieldType}
+		if got := field.SQLTypeFor(tt.dialect); got != tt.expected {
+			t.Errorf("SQLTypeFor(%q) for %s = %v, want %v", tt.dialect, tt.fieldType, got, tt.expected)
+		}
+	}
+}
+
+func TestTableName(t *testing.T) {
+	tests := []struct {
+		entity   string
+		expected string
+	}{
+		{"user", "users"},
+		{"orderItem", "order_items"},
+		{"order-item", "order_items"},
+		{"category", "categories"},
+		{"day", "days"},
+		{"address", "addresses"},
+		{"box", "boxes"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entity, func(t *testing.T) {
+			if got := TableName(tt.entity); got != tt.expected {
+				t.Errorf("TableName(%q) = %q, want %q", tt.entity, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"products", "product"},
+		{"categories", "category"},
+		{"days", "day"},
+		{"addresses", "address"},
+		{"boxes", "box"},
+		{"status", "status"},
+		{"user", "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := Singular(tt.word); got != tt.expected {
+				t.Errorf("Singular(%q) = %q, want %q", tt.word, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		input  string
+		snake  string
+		pascal string
+	}{
+		{"user", "user", "User"},
+		{"orderItem", "order_item", "OrderItem"},
+		{"order-item", "order_item", "OrderItem"},
+		{"user_id", "user_id", "UserID"},
+		{"HTTPStatus", "http_status", "HttpStatus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ToSnakeCase(tt.input); got != tt.snake {
+				t.Errorf("ToSnakeCase(%q) = %v, want %v", tt.input, got, tt.snake)
+			}
+			if got := ToPascalCase(tt.input); got != tt.pascal {
+				t.Errorf("ToPascalCase(%q) = %v, want %v", tt.input, got, tt.pascal)
+			}
+		})
+	}
+}
+
+func TestInitialisms(t *testing.T) {
+	names := Initialisms()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Initialisms() = %v, want sorted", names)
+	}
+	for _, name := range names {
+		if got := ToPascalCase(name); got != strings.ToUpper(name) {
+			t.Errorf("ToPascalCase(%q) = %v, want %v", name, got, strings.ToUpper(name))
+		}
+	}
+}
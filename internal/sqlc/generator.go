@@ -0,0 +1,42 @@
+package sqlc
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Tool is the sqlc executable
+const Tool = "sqlc"
+
+// Generator runs `sqlc generate` for a project
+type Generator struct {
+	projectRoot string
+}
+
+// NewGenerator creates a new sqlc generator for the project
+func NewGenerator(projectRoot string) *Generator {
+	return &Generator{
+		projectRoot: projectRoot,
+	}
+}
+
+// Installed reports whether sqlc is on PATH
+func (g *Generator) Installed() bool {
+	_, err := exec.LookPath(Tool)
+	return err == nil
+}
+
+// Generate compiles the queries in queries/ against the migrations as configured by sqlc.yaml
+func (g *Generator) Generate() error {
+	cmd := exec.Command(Tool, "generate")
+	cmd.Dir = g.projectRoot
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sqlc generate failed: %w", err)
+	}
+
+	return nil
+}
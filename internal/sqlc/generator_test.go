@@ -0,0 +1,54 @@
+package sqlc
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_Installed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	bin := t.TempDir()
+	t.Setenv("PATH", bin)
+	if NewGenerator("project").Installed() {
+		t.Error("Installed() = true with an empty PATH")
+	}
+
+	if err := os.WriteFile(filepath.Join(bin, Tool), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !NewGenerator("project").Installed() {
+		t.Error("Installed() = false with sqlc on PATH")
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" > args.txt\n"
+	if err := os.WriteFile(filepath.Join(bin, Tool), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	projectRoot := t.TempDir()
+	if err := NewGenerator(projectRoot).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectRoot, "args.txt"))
+	if err != nil {
+		t.Fatalf("sqlc did not run in the project root: %v", err)
+	}
+	if strings.TrimSpace(string(content)) != "generate" {
+		t.Errorf("sqlc args = %s", content)
+	}
+}
@@ -1,12 +1,15 @@
 package template
 
 import (
+	"bytes"
 	"embed"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
+
+	"github.com/indalyadav56/gogen/internal/schema"
 )
 
 // Renderer handles template rendering operations
@@ -31,6 +34,43 @@ type Data struct {
 	IsMonolith  bool
 	UseGin      bool
 	UseAuth     bool
+	// Framework is the HTTP framework the project is generated for (chi, gin, echo, fiber or stdlib)
+	Framework string
+	// DB is the SQL dialect of the project (postgres, mysql or sqlite)
+	DB string
+	// DBPackage is the infrastructure package holding the repository implementation
+	DBPackage string
+	// UsePgx generates the Postgres repository and connection pool on pgx instead of database/sql
+	UsePgx bool
+	// UseGORM generates the repositories and connection pool on GORM
+	UseGORM bool
+	// UseSQLC backs the Postgres repositories with sqlc-generated queries
+	UseSQLC bool
+	// SQLCDir is the directory, relative to the project root, sqlc writes its Go code to
+	SQLCDir string
+	// Initialisms lists the lower case initialisms upper-cased in generated Go identifiers
+	Initialisms []string
+	// UseMigrate wires the embedded migration runner into cmd/main.go
+	UseMigrate bool
+	// Fields of the entity being rendered
+	Fields schema.Fields
+	// TableName is the database table of the entity being rendered
+	TableName string
+	// RoutePath is the base path the entity's routes are mounted at
+	RoutePath string
+	// UseSwagger serves the OpenAPI spec and a Swagger UI page from cmd/main.go
+	UseSwagger bool
+	// UseGRPC starts a gRPC server next to HTTP in cmd/main.go
+	UseGRPC bool
+	// UseTests adds a test task to the Taskfile
+	UseTests bool
+	// UseIntegration adds a test-integration task to the Taskfile
+	UseIntegration bool
+	// UseCursor pages the list endpoints with signed keyset cursors instead of page numbers
+	UseCursor bool
+	// DependentTables lists the tables referencing the entity's rows, directly or not, in the
+	// order they can be cleared
+	DependentTables []string
 	// Import paths for different architectures
 	HandlerImport     string
 	ServiceImport     string
@@ -38,6 +78,9 @@ type Data struct {
 	EntityImport      string
 	InfraImport       string
 	RoutesImport      string
+	DTOImport         string
+	GRPCImport        string
+	ProtoImport       string
 	// Auth-specific import paths for separate bounded contexts
 	UserEntityImport      string
 	UserRepositoryImport  string
@@ -52,6 +95,27 @@ type Data struct {
 
 // RenderToFile renders a template to a file
 func (r *Renderer) RenderToFile(templatePath, outputPath string, data Data) error {
+	content, err := r.Render(templatePath, data)
+	if err != nil {
+		return err
+	}
+
+	// Create file to write rendered content
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(content); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	return nil
+}
+
+// Render renders a template and returns the resulting content
+func (r *Renderer) Render(templatePath string, data Data) ([]byte, error) {
 	// Define custom template functions
 	funcMap := template.FuncMap{
 		"ToLower": func(s string) string {
@@ -72,6 +136,9 @@ func (r *Renderer) RenderToFile(templatePath, outputPath string, data Data) erro
 			}
 			return strings.ToUpper(s[:1]) + s[1:]
 		},
+		"ToSnakeCase": schema.ToSnakeCase,
+		"join":        strings.Join,
+		"add":         func(a, b int) int { return a + b },
 	}
 
 	// Ensure template path uses forward slashes for embedded filesystem
@@ -80,26 +147,20 @@ func (r *Renderer) RenderToFile(templatePath, outputPath string, data Data) erro
 	// Read template content from embedded filesystem
 	templateContent, err := r.templateFS.ReadFile(templatePathNormalized)
 	if err != nil {
-		return fmt.Errorf("failed to read embedded template file %s: %w", templatePathNormalized, err)
+		return nil, fmt.Errorf("failed to read embedded template file %s: %w", templatePathNormalized, err)
 	}
 
 	// Parse template from content with custom functions
 	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(funcMap).Parse(string(templateContent))
 	if err != nil {
-		return fmt.Errorf("failed to parse template content: %w", err)
-	}
-
-	// Create file to write rendered content
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return nil, fmt.Errorf("failed to parse template content: %w", err)
 	}
-	defer file.Close()
 
 	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return nil
+	return buf.Bytes(), nil
 }